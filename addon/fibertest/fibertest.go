@@ -0,0 +1,176 @@
+// Package fibertest provides a fluent harness for testing Fiber apps.
+//
+// Requests are sent with the Fiber client over in-memory connections, so no
+// network is used, and cookies set by the app are sent with later requests.
+package fibertest
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/valyala/fasthttp"
+)
+
+// Harness sends requests to an app and asserts on the responses.
+type Harness struct {
+	tb     testing.TB
+	app    *fiber.App
+	client *fiber.Client
+	jar    *fiber.MemoryCookieJar
+	config Config
+}
+
+// New returns a Harness for app. Failed assertions are reported to tb.
+func New(tb testing.TB, app *fiber.App, config ...Config) *Harness {
+	tb.Helper()
+
+	cfg := configDefault(config...)
+	jar := fiber.NewCookieJar()
+
+	return &Harness{
+		tb:  tb,
+		app: app,
+		client: &fiber.Client{
+			App:                  app,
+			BaseURL:              cfg.BaseURL,
+			Headers:              cfg.Headers,
+			CookieJar:            jar,
+			DisableDecompression: true,
+		},
+		jar:    jar,
+		config: cfg,
+	}
+}
+
+// App returns the app under test.
+func (h *Harness) App() *fiber.App {
+	return h.app
+}
+
+// Get returns a GET request to path.
+func (h *Harness) Get(path string) *Request {
+	return h.Request(fiber.MethodGet, path)
+}
+
+// Head returns a HEAD request to path.
+func (h *Harness) Head(path string) *Request {
+	return h.Request(fiber.MethodHead, path)
+}
+
+// Post returns a POST request to path.
+func (h *Harness) Post(path string) *Request {
+	return h.Request(fiber.MethodPost, path)
+}
+
+// Put returns a PUT request to path.
+func (h *Harness) Put(path string) *Request {
+	return h.Request(fiber.MethodPut, path)
+}
+
+// Patch returns a PATCH request to path.
+func (h *Harness) Patch(path string) *Request {
+	return h.Request(fiber.MethodPatch, path)
+}
+
+// Delete returns a DELETE request to path.
+func (h *Harness) Delete(path string) *Request {
+	return h.Request(fiber.MethodDelete, path)
+}
+
+// Options returns an OPTIONS request to path.
+func (h *Harness) Options(path string) *Request {
+	return h.Request(fiber.MethodOptions, path)
+}
+
+// Request returns a request to path with the given method. The path is
+// relative to the BaseURL of the Config unless it is an absolute URL.
+func (h *Harness) Request(method, path string) *Request {
+	return &Request{
+		h:       h,
+		method:  method,
+		path:    path,
+		timeout: h.config.Timeout,
+	}
+}
+
+// Cookie returns the value of the cookie stored for the given URL, or for
+// the BaseURL of the Config if no URL is given.
+func (h *Harness) Cookie(name string, rawURL ...string) string {
+	target := h.config.BaseURL
+	if len(rawURL) > 0 {
+		target = rawURL[0]
+	}
+
+	uri := fasthttp.AcquireURI()
+	defer fasthttp.ReleaseURI(uri)
+	if err := uri.Parse(nil, []byte(target)); err != nil {
+		h.tb.Fatalf("fibertest: invalid url %q: %v", target, err)
+	}
+
+	for _, c := range h.jar.Cookies(uri) {
+		if string(c.Key()) == name {
+			return string(c.Value())
+		}
+	}
+
+	return ""
+}
+
+// Config defines the config for the Harness.
+type Config struct {
+	// BaseURL is prepended to the path of every request. Only http URLs
+	// are supported.
+	//
+	// Optional. Default: "http://example.com"
+	BaseURL string
+
+	// Headers are set on every request.
+	//
+	// Optional. Default: nil
+	Headers map[string]string
+
+	// Timeout is the default timeout of every request, it can be overridden
+	// per request with Request.Timeout. Use a negative value to wait until
+	// the handler returns.
+	//
+	// Optional. Default: 1 * time.Second
+	Timeout time.Duration
+}
+
+// ConfigDefault is the default config.
+var ConfigDefault = Config{
+	BaseURL: "http://example.com",
+	Timeout: 1 * time.Second,
+}
+
+// configDefault sets default values for config.
+func configDefault(config ...Config) Config {
+	if len(config) < 1 {
+		return ConfigDefault
+	}
+
+	cfg := config[0]
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = ConfigDefault.BaseURL
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	if cfg.Timeout == 0 {
+		cfg.Timeout = ConfigDefault.Timeout
+	}
+
+	return cfg
+}
+
+// encodeQuery appends query to path, keeping an existing query string.
+func encodeQuery(path string, query url.Values) string {
+	if len(query) == 0 {
+		return path
+	}
+	if strings.Contains(path, "?") {
+		return path + "&" + query.Encode()
+	}
+	return path + "?" + query.Encode()
+}
@@ -0,0 +1,193 @@
+package fibertest
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder records the failures reported by a Harness.
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (*recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+	r.fatal = true
+}
+
+func testApp() *fiber.App {
+	app := fiber.New()
+
+	app.Get("/users/:id", func(c fiber.Ctx) error {
+		c.Set("X-Request-Id", c.Get("X-Request-Id"))
+		return c.JSON(fiber.Map{
+			"id":    c.Params("id"),
+			"roles": []string{"admin", c.Query("role", "user")},
+			"meta":  fiber.Map{"active": true, "score": 4.5},
+		})
+	})
+	app.Post("/users", func(c fiber.Ctx) error {
+		var user struct {
+			Name string `json:"name"`
+		}
+		if err := c.Bind().Body(&user); err != nil {
+			return err
+		}
+		return c.Status(fiber.StatusCreated).SendString("created " + user.Name)
+	})
+	app.Post("/form", func(c fiber.Ctx) error {
+		return c.SendString(c.FormValue("name"))
+	})
+	app.Post("/upload", func(c fiber.Ctx) error {
+		fh, err := c.FormFile("file")
+		if err != nil {
+			return err
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return err
+		}
+		defer f.Close() //nolint:errcheck // We're in a test
+		content, err := io.ReadAll(f)
+		if err != nil {
+			return err
+		}
+		return c.SendString(c.FormValue("name") + ":" + fh.Filename + ":" + string(content))
+	})
+	app.Post("/login", func(c fiber.Ctx) error {
+		c.Cookie(&fiber.Cookie{Name: "session", Value: "secret"})
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Get("/me", func(c fiber.Ctx) error {
+		return c.SendString(c.Cookies("session") + c.Cookies("lang"))
+	})
+	app.Delete("/slow", func(c fiber.Ctx) error {
+		time.Sleep(100 * time.Millisecond)
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	return app
+}
+
+// go test -run Test_Harness_JSON
+func Test_Harness_JSON(t *testing.T) {
+	t.Parallel()
+
+	h := New(t, testApp())
+
+	h.Get("/users/42").
+		Query("role", "editor").
+		Header("X-Request-Id", "abc").
+		Send().
+		ExpectStatus(fiber.StatusOK).
+		ExpectHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+		ExpectHeader("X-Request-Id", "abc").
+		ExpectJSON("id", "42").
+		ExpectJSON("roles", []string{"admin", "editor"}).
+		ExpectJSON("roles.1", "editor").
+		ExpectJSON("meta", map[string]any{"active": true, "score": 4.5}).
+		ExpectJSON("meta.score", 4.5).
+		ExpectBodyContains(`"active":true`)
+
+	var user struct {
+		ID string `json:"id"`
+	}
+	h.Get("/users/7").Send().JSON(&user)
+	require.Equal(t, "7", user.ID)
+
+	h.Post("/users").
+		JSON(fiber.Map{"name": "john"}).
+		Send().
+		ExpectStatus(fiber.StatusCreated).
+		ExpectBody("created john")
+}
+
+// go test -run Test_Harness_Form
+func Test_Harness_Form(t *testing.T) {
+	t.Parallel()
+
+	h := New(t, testApp())
+
+	h.Post("/form").
+		FormValue("name", "john doe").
+		Send().
+		ExpectStatus(fiber.StatusOK).
+		ExpectBody("john doe")
+
+	h.Post("/upload").
+		FormValue("name", "report").
+		File("file", "report.txt", []byte("content")).
+		Send().
+		ExpectStatus(fiber.StatusOK).
+		ExpectBody("report:report.txt:content")
+}
+
+// go test -run Test_Harness_Cookies
+func Test_Harness_Cookies(t *testing.T) {
+	t.Parallel()
+
+	h := New(t, testApp(), Config{
+		BaseURL: "http://api.example.com/",
+		Headers: map[string]string{"X-Request-Id": "default"},
+	})
+
+	h.Get("/me").Send().ExpectBody("")
+	h.Post("/login").Send().ExpectStatus(fiber.StatusNoContent)
+	require.Equal(t, "secret", h.Cookie("session"))
+	require.Equal(t, "", h.Cookie("session", "http://other.example.com"))
+
+	h.Get("/me").Cookie("lang", "en").Send().ExpectBody("secreten")
+	h.Get("/users/1").Send().ExpectHeader("X-Request-Id", "default")
+}
+
+// go test -run Test_Harness_Timeout
+func Test_Harness_Timeout(t *testing.T) {
+	t.Parallel()
+
+	h := New(t, testApp())
+	h.Delete("/slow").Timeout(time.Second).Send().ExpectStatus(fiber.StatusNoContent)
+	h.Delete("/slow").Timeout(-1).Send().ExpectStatus(fiber.StatusNoContent)
+
+	r := &recorder{TB: t}
+	New(r, testApp(), Config{Timeout: 10 * time.Millisecond}).Delete("/slow").Send()
+	require.True(t, r.fatal)
+	require.Contains(t, r.errors[0], "DELETE /slow failed")
+}
+
+// go test -run Test_Harness_FailedAssertions
+func Test_Harness_FailedAssertions(t *testing.T) {
+	t.Parallel()
+
+	r := &recorder{TB: t}
+	New(r, testApp()).Get("/users/1").Send().
+		ExpectStatus(fiber.StatusNotFound).
+		ExpectHeader("X-Missing", "value").
+		ExpectBody("nope").
+		ExpectBodyContains("nope").
+		ExpectJSON("id", 1).
+		ExpectJSON("roles.5", "user").
+		ExpectJSON("meta.active", true)
+
+	require.False(t, r.fatal)
+	require.Equal(t, []string{
+		`fibertest: expected status 404, got 200 with body "{\"id\":\"1\",\"meta\":{\"active\":true,\"score\":4.5},\"roles\":[\"admin\",\"user\"]}"`,
+		`fibertest: expected header X-Missing to be "value", got ""`,
+		`fibertest: expected body "nope", got "{\"id\":\"1\",\"meta\":{\"active\":true,\"score\":4.5},\"roles\":[\"admin\",\"user\"]}"`,
+		`fibertest: expected body to contain "nope", got "{\"id\":\"1\",\"meta\":{\"active\":true,\"score\":4.5},\"roles\":[\"admin\",\"user\"]}"`,
+		`fibertest: expected JSON path "id" to be 1, got "1"`,
+		`fibertest: expected JSON path "roles.5" in {"id":"1","meta":{"active":true,"score":4.5},"roles":["admin","user"]}`,
+	}, r.errors)
+}
@@ -0,0 +1,157 @@
+package fibertest
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// Request is a request built by a Harness. It is sent by Send.
+type Request struct {
+	h       *Harness
+	method  string
+	path    string
+	headers [][2]string
+	cookies [][2]string
+	query   url.Values
+	body    []byte
+	json    any
+	form    url.Values
+	files   []*fiber.FormFile
+	timeout time.Duration
+	isJSON  bool
+	isForm  bool
+}
+
+// Header sets a request header.
+func (r *Request) Header(key, value string) *Request {
+	r.headers = append(r.headers, [2]string{key, value})
+
+	return r
+}
+
+// Cookie sets a request cookie in addition to the cookies stored by the
+// Harness.
+func (r *Request) Cookie(key, value string) *Request {
+	r.cookies = append(r.cookies, [2]string{key, value})
+
+	return r
+}
+
+// Query adds a query parameter.
+func (r *Request) Query(key, value string) *Request {
+	if r.query == nil {
+		r.query = url.Values{}
+	}
+	r.query.Add(key, value)
+
+	return r
+}
+
+// Body sets the raw request body.
+func (r *Request) Body(body []byte) *Request {
+	r.body = body
+
+	return r
+}
+
+// JSON sets v encoded as JSON as the request body.
+func (r *Request) JSON(v any) *Request {
+	r.json, r.isJSON = v, true
+
+	return r
+}
+
+// FormValue adds a form field. The body is url encoded, or multipart encoded
+// if files are added too.
+func (r *Request) FormValue(key, value string) *Request {
+	if r.form == nil {
+		r.form = url.Values{}
+	}
+	r.form.Add(key, value)
+	r.isForm = true
+
+	return r
+}
+
+// File adds a file to the multipart form body.
+func (r *Request) File(fieldname, filename string, content []byte) *Request {
+	r.files = append(r.files, &fiber.FormFile{
+		Fieldname: fieldname,
+		Name:      filename,
+		Content:   content,
+	})
+
+	return r
+}
+
+// Timeout sets the timeout of the request, overriding the Timeout of the
+// Config. Use a negative value to wait until the handler returns.
+func (r *Request) Timeout(timeout time.Duration) *Request {
+	r.timeout = timeout
+
+	return r
+}
+
+// Send sends the request and returns the response. The test fails
+// immediately if the request can't be sent.
+func (r *Request) Send() *Response {
+	tb := r.h.tb
+	tb.Helper()
+
+	a := r.h.client.Get(encodeQuery(r.path, r.query))
+	a.Request().Header.SetMethod(r.method)
+	for _, kv := range r.headers {
+		a.Set(kv[0], kv[1])
+	}
+	for _, kv := range r.cookies {
+		a.Cookie(kv[0], kv[1])
+	}
+	if r.timeout > 0 {
+		a.Timeout(r.timeout)
+	}
+
+	switch {
+	case len(r.files) > 0:
+		args := fiber.AcquireArgs()
+		defer fiber.ReleaseArgs(args)
+		for key, values := range r.form {
+			for _, value := range values {
+				args.Add(key, value)
+			}
+		}
+		a.FileData(r.files...).MultipartForm(args)
+	case r.isForm:
+		args := fiber.AcquireArgs()
+		defer fiber.ReleaseArgs(args)
+		args.Parse(r.form.Encode())
+		a.Form(args)
+	case r.isJSON:
+		a.JSON(r.json)
+	case r.body != nil:
+		a.Body(r.body)
+	}
+
+	resp := fiber.AcquireResponse()
+	defer fiber.ReleaseResponse(resp)
+
+	code, body, errs := a.SetResponse(resp).Bytes()
+	if len(errs) > 0 {
+		tb.Fatalf("fibertest: %s %s failed: %v", r.method, r.path, errs)
+		return nil
+	}
+
+	res := &Response{
+		tb:         tb,
+		StatusCode: code,
+		Header:     make(http.Header),
+		Body:       append([]byte(nil), body...),
+	}
+	resp.Header.VisitAll(func(key, value []byte) {
+		res.Header.Add(string(key), string(value))
+	})
+
+	return res
+}
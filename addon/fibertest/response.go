@@ -0,0 +1,165 @@
+package fibertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Response is the response to a Request.
+//
+// The Expect methods report failed assertions to the test without stopping
+// it, so several assertions can be chained.
+type Response struct {
+	tb testing.TB
+
+	// StatusCode is the response status code.
+	StatusCode int
+
+	// Header holds the response headers.
+	Header http.Header
+
+	// Body is the response body.
+	Body []byte
+}
+
+// String returns the response body.
+func (r *Response) String() string {
+	return string(r.Body)
+}
+
+// JSON decodes the JSON response body into v. The test fails immediately if
+// the body can't be decoded.
+func (r *Response) JSON(v any) {
+	r.tb.Helper()
+
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		r.tb.Fatalf("fibertest: failed to decode response body %q: %v", r.Body, err)
+	}
+}
+
+// ExpectStatus asserts the response status code.
+func (r *Response) ExpectStatus(code int) *Response {
+	r.tb.Helper()
+
+	if r.StatusCode != code {
+		r.tb.Errorf("fibertest: expected status %d, got %d with body %q", code, r.StatusCode, r.Body)
+	}
+
+	return r
+}
+
+// ExpectHeader asserts the value of a response header. An empty value
+// asserts that the header is not set.
+func (r *Response) ExpectHeader(key, value string) *Response {
+	r.tb.Helper()
+
+	if actual := r.Header.Get(key); actual != value {
+		r.tb.Errorf("fibertest: expected header %s to be %q, got %q", key, value, actual)
+	}
+
+	return r
+}
+
+// ExpectBody asserts the response body.
+func (r *Response) ExpectBody(body string) *Response {
+	r.tb.Helper()
+
+	if string(r.Body) != body {
+		r.tb.Errorf("fibertest: expected body %q, got %q", body, r.Body)
+	}
+
+	return r
+}
+
+// ExpectBodyContains asserts that the response body contains s.
+func (r *Response) ExpectBodyContains(s string) *Response {
+	r.tb.Helper()
+
+	if !bytes.Contains(r.Body, []byte(s)) {
+		r.tb.Errorf("fibertest: expected body to contain %q, got %q", s, r.Body)
+	}
+
+	return r
+}
+
+// ExpectJSON asserts that the value at path in the JSON response body equals
+// expected once both are encoded as JSON.
+//
+// The path consists of object keys and array indexes separated by dots,
+// e.g. "users.0.name". An empty path selects the whole body.
+func (r *Response) ExpectJSON(path string, expected any) *Response {
+	r.tb.Helper()
+
+	var body any
+	if err := json.Unmarshal(r.Body, &body); err != nil {
+		r.tb.Errorf("fibertest: expected a JSON body, got %q: %v", r.Body, err)
+		return r
+	}
+
+	actual, ok := lookupJSONPath(body, path)
+	if !ok {
+		r.tb.Errorf("fibertest: expected JSON path %q in %s", path, r.Body)
+		return r
+	}
+
+	want, err := normalizeJSON(expected)
+	if err != nil {
+		r.tb.Errorf("fibertest: failed to encode expected value %v: %v", expected, err)
+		return r
+	}
+	if !reflect.DeepEqual(actual, want) {
+		actualJSON, _ := json.Marshal(actual) //nolint:errcheck // The value was decoded from JSON
+		wantJSON, _ := json.Marshal(want)     //nolint:errcheck // The value was decoded from JSON
+		r.tb.Errorf("fibertest: expected JSON path %q to be %s, got %s", path, wantJSON, actualJSON)
+	}
+
+	return r
+}
+
+// lookupJSONPath returns the value at path in the decoded JSON value v.
+func lookupJSONPath(v any, path string) (any, bool) {
+	if path == "" {
+		return v, true
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]any:
+			var ok bool
+			if v, ok = node[key]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			v = node[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return v, true
+}
+
+// normalizeJSON encodes v as JSON and decodes it again, so it can be compared
+// with a decoded body.
+func normalizeJSON(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Reported by the caller
+	}
+
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err //nolint:wrapcheck // Reported by the caller
+	}
+
+	return normalized, nil
+}
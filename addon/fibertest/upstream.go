@@ -0,0 +1,266 @@
+package fibertest
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/utils/v2"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+// Upstream is a scriptable upstream server for testing proxies and clients.
+//
+// It is served by a Fiber app on an in-memory listener, connections are
+// established with Dial. Every received request is recorded, and answered
+// with the replies scripted with On, or with 404 if none matches.
+type Upstream struct {
+	app          *fiber.App
+	ln           *fasthttputil.InmemoryListener
+	done         chan struct{}
+	mu           sync.Mutex
+	requests     []RecordedRequest
+	expectations []*Expectation
+}
+
+// RecordedRequest is a request received by an Upstream.
+type RecordedRequest struct {
+	// Method is the request method.
+	Method string
+
+	// URI is the path and query string of the request.
+	URI string
+
+	// Host is the value of the Host header.
+	Host string
+
+	// Header holds the request headers.
+	Header http.Header
+
+	// Body is the request body.
+	Body []byte
+}
+
+// NewUpstream starts an Upstream which is shut down when the test finishes.
+func NewUpstream(tb testing.TB) *Upstream {
+	tb.Helper()
+
+	u := &Upstream{
+		app:  fiber.New(),
+		ln:   fasthttputil.NewInmemoryListener(),
+		done: make(chan struct{}),
+	}
+	u.app.Use(u.handler)
+
+	go func() {
+		if err := u.app.Listener(u.ln, fiber.ListenConfig{DisableStartupMessage: true}); err != nil {
+			tb.Errorf("fibertest: upstream stopped: %v", err)
+		}
+	}()
+	tb.Cleanup(func() {
+		close(u.done)
+		_ = u.app.Shutdown() //nolint:errcheck // The test is already finished
+	})
+
+	return u
+}
+
+// Dial establishes a new connection to the Upstream, whatever addr is. It can
+// be used as the Dial function of a fiber.Client or fasthttp client.
+func (u *Upstream) Dial(_ string) (net.Conn, error) {
+	return u.ln.Dial() //nolint:wrapcheck // This must not be wrapped
+}
+
+// On scripts the replies to requests with the given method and path. The
+// path is matched exactly, without the query string. An empty method or
+// path matches every request.
+//
+// Expectations are matched in the order they were added.
+func (u *Upstream) On(method, path string) *Expectation {
+	e := &Expectation{u: u, method: method, path: path}
+
+	u.mu.Lock()
+	u.expectations = append(u.expectations, e)
+	u.mu.Unlock()
+
+	return e
+}
+
+// Requests returns the requests received so far.
+func (u *Upstream) Requests() []RecordedRequest {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return append([]RecordedRequest(nil), u.requests...)
+}
+
+// handler records the request and sends the next reply of the first matching
+// expectation.
+func (u *Upstream) handler(c fiber.Ctx) error {
+	req := RecordedRequest{
+		Method: utils.CopyString(c.Method()),
+		URI:    utils.CopyString(c.OriginalURL()),
+		Host:   string(c.Request().Host()),
+		Header: make(http.Header),
+		Body:   append([]byte(nil), c.Body()...),
+	}
+	c.Request().Header.VisitAll(func(key, value []byte) {
+		req.Header.Add(string(key), string(value))
+	})
+
+	u.mu.Lock()
+	u.requests = append(u.requests, req)
+	var reply *Reply
+	for _, e := range u.expectations {
+		if e.match(req.Method, c.Path()) {
+			reply = e.next()
+			break
+		}
+	}
+	u.mu.Unlock()
+
+	if reply == nil {
+		return c.Status(fiber.StatusNotFound).SendString("fibertest: no reply for " + req.Method + " " + req.URI)
+	}
+
+	return reply.send(c, u.done)
+}
+
+// Expectation holds the scripted replies to matching requests.
+type Expectation struct {
+	u       *Upstream
+	method  string
+	path    string
+	replies []*Reply
+	calls   int
+}
+
+// Reply adds a reply with the given status code and body. Every request
+// gets the next reply, and the last reply is repeated once all were sent.
+func (e *Expectation) Reply(status int, body string) *Reply {
+	return e.add(&Reply{status: status, body: []byte(body)})
+}
+
+// ReplyJSON adds a reply with the given status code and v encoded as JSON.
+func (e *Expectation) ReplyJSON(status int, v any) *Reply {
+	return e.add(&Reply{status: status, json: v, isJSON: true})
+}
+
+// Handle adds a reply sent by handler.
+func (e *Expectation) Handle(handler fiber.Handler) *Reply {
+	return e.add(&Reply{status: fiber.StatusOK, handler: handler})
+}
+
+// Reset adds a reply closing the connection without sending a response.
+//
+// Note that fasthttp clients resend requests failing this way, up to the
+// MaxIdemponentCallAttempts of the client.
+func (e *Expectation) Reset() *Reply {
+	return e.add(&Reply{reset: true})
+}
+
+// Calls returns the number of requests matched by the Expectation.
+func (e *Expectation) Calls() int {
+	e.u.mu.Lock()
+	defer e.u.mu.Unlock()
+
+	return e.calls
+}
+
+func (e *Expectation) add(r *Reply) *Reply {
+	r.e = e
+
+	e.u.mu.Lock()
+	e.replies = append(e.replies, r)
+	e.u.mu.Unlock()
+
+	return r
+}
+
+func (e *Expectation) match(method, path string) bool {
+	return (e.method == "" || e.method == method) && (e.path == "" || e.path == path)
+}
+
+func (e *Expectation) next() *Reply {
+	e.calls++
+	if len(e.replies) == 0 {
+		return nil
+	}
+	if e.calls > len(e.replies) {
+		return e.replies[len(e.replies)-1]
+	}
+	return e.replies[e.calls-1]
+}
+
+// Reply is a scripted reply of an Upstream.
+type Reply struct {
+	e       *Expectation
+	status  int
+	headers [][2]string
+	body    []byte
+	json    any
+	handler fiber.Handler
+	delay   time.Duration
+	reset   bool
+	isJSON  bool
+}
+
+// Header sets a response header.
+func (r *Reply) Header(key, value string) *Reply {
+	r.e.u.mu.Lock()
+	r.headers = append(r.headers, [2]string{key, value})
+	r.e.u.mu.Unlock()
+
+	return r
+}
+
+// Delay delays the reply, e.g. to trigger timeouts.
+func (r *Reply) Delay(delay time.Duration) *Reply {
+	r.e.u.mu.Lock()
+	r.delay = delay
+	r.e.u.mu.Unlock()
+
+	return r
+}
+
+// Then returns the Expectation of the Reply to script the next reply.
+func (r *Reply) Then() *Expectation {
+	return r.e
+}
+
+func (r *Reply) send(c fiber.Ctx, done <-chan struct{}) error {
+	r.e.u.mu.Lock()
+	delay, headers := r.delay, r.headers
+	r.e.u.mu.Unlock()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-done:
+			timer.Stop()
+		}
+	}
+
+	if r.reset {
+		c.Context().HijackSetNoResponse(true)
+		c.Context().Hijack(func(net.Conn) {})
+		return nil
+	}
+
+	for _, kv := range headers {
+		c.Set(kv[0], kv[1])
+	}
+	if r.handler != nil {
+		return r.handler(c)
+	}
+	c.Status(r.status)
+	if r.isJSON {
+		return c.JSON(r.json)
+	}
+
+	return c.Send(r.body)
+}
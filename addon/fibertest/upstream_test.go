@@ -0,0 +1,140 @@
+package fibertest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/addon/retry"
+	"github.com/gofiber/fiber/v3/middleware/proxy"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// go test -run Test_Upstream_Replies
+func Test_Upstream_Replies(t *testing.T) {
+	t.Parallel()
+
+	u := NewUpstream(t)
+	items := u.On(fiber.MethodGet, "/items")
+	items.Reply(fiber.StatusServiceUnavailable, "busy").
+		Then().
+		ReplyJSON(fiber.StatusOK, fiber.Map{"items": []int{1, 2}}).
+		Header("X-Upstream", "1")
+	u.On(fiber.MethodPost, "/items").Handle(func(c fiber.Ctx) error {
+		return c.Status(fiber.StatusCreated).Send(c.Body())
+	})
+
+	c := &fiber.Client{Dial: u.Dial}
+
+	code, body, errs := c.Get("http://upstream.local/items?page=1").String()
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, fiber.StatusServiceUnavailable, code)
+	require.Equal(t, "busy", body)
+
+	// The last reply is repeated
+	for i := 0; i < 2; i++ {
+		code, body, errs = c.Get("http://upstream.local/items").String()
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, fiber.StatusOK, code)
+		require.Equal(t, `{"items":[1,2]}`, body)
+	}
+	require.Equal(t, 3, items.Calls())
+
+	code, body, errs = c.Post("http://upstream.local/items").Body([]byte("item")).String()
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, fiber.StatusCreated, code)
+	require.Equal(t, "item", body)
+
+	code, body, errs = c.Get("http://upstream.local/unknown").String()
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, fiber.StatusNotFound, code)
+	require.Equal(t, "fibertest: no reply for GET /unknown", body)
+
+	requests := u.Requests()
+	require.Len(t, requests, 5)
+	require.Equal(t, fiber.MethodGet, requests[0].Method)
+	require.Equal(t, "/items?page=1", requests[0].URI)
+	require.Equal(t, "upstream.local", requests[0].Host)
+	require.Equal(t, "item", string(requests[3].Body))
+}
+
+// go test -run Test_Upstream_Retry
+func Test_Upstream_Retry(t *testing.T) {
+	t.Parallel()
+
+	u := NewUpstream(t)
+	flaky := u.On("", "/flaky")
+	flaky.Reply(fiber.StatusBadGateway, "").
+		Then().
+		Reply(fiber.StatusServiceUnavailable, "").
+		Then().
+		Reply(fiber.StatusOK, "ok")
+
+	c := &fiber.Client{
+		Dial: u.Dial,
+		Retry: &fiber.RetryConfig{Backoff: retry.Config{
+			InitialInterval: time.Millisecond,
+			MaxBackoffTime:  time.Millisecond,
+			MaxRetryCount:   5,
+			MaxJitter:       time.Millisecond,
+		}},
+	}
+
+	a := c.Get("http://upstream.local/flaky")
+	code, body, errs := a.Reuse().String()
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, fiber.StatusOK, code)
+	require.Equal(t, "ok", body)
+	require.Equal(t, 3, a.Attempts())
+	require.Equal(t, 3, flaky.Calls())
+	fiber.ReleaseAgent(a)
+}
+
+// go test -run Test_Upstream_DelayAndReset
+func Test_Upstream_DelayAndReset(t *testing.T) {
+	t.Parallel()
+
+	u := NewUpstream(t)
+	u.On(fiber.MethodGet, "/slow").Reply(fiber.StatusOK, "slow").Delay(time.Second)
+	reset := u.On(fiber.MethodPost, "/reset")
+	reset.Reset()
+
+	c := &fiber.Client{Dial: u.Dial, Timeout: 20 * time.Millisecond}
+
+	_, _, errs := c.Get("http://upstream.local/slow").String()
+	require.Equal(t, 1, len(errs))
+	require.ErrorIs(t, errs[0], fasthttp.ErrTimeout)
+
+	hc := &fasthttp.HostClient{Addr: "upstream.local:80", Dial: u.Dial, MaxIdemponentCallAttempts: 1}
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.Header.SetMethod(fiber.MethodPost)
+	req.SetRequestURI("http://upstream.local/reset")
+	require.ErrorIs(t, hc.Do(req, nil), fasthttp.ErrConnectionClosed)
+	require.Equal(t, 1, reset.Calls())
+}
+
+// go test -run Test_Upstream_Proxy
+func Test_Upstream_Proxy(t *testing.T) {
+	t.Parallel()
+
+	u := NewUpstream(t)
+	u.On(fiber.MethodPost, "/api/users").Reply(fiber.StatusCreated, "created").Header("X-Upstream", "users")
+
+	app := fiber.New()
+	app.Post("/users", proxy.Forward("http://users.internal/api/users", &fasthttp.Client{Dial: u.Dial}))
+
+	New(t, app).Post("/users").
+		JSON(fiber.Map{"name": "john"}).
+		Send().
+		ExpectStatus(fiber.StatusCreated).
+		ExpectHeader("X-Upstream", "users").
+		ExpectBody("created")
+
+	requests := u.Requests()
+	require.Len(t, requests, 1)
+	require.Equal(t, "users.internal", requests[0].Host)
+	require.Equal(t, fiber.MIMEApplicationJSON, requests[0].Header.Get(fiber.HeaderContentType))
+	require.Equal(t, `{"name":"john"}`, string(requests[0].Body))
+}
@@ -28,6 +28,12 @@ type Config struct {
 	// Optional. Default: 10
 	MaxRetryCount int
 
+	// MaxJitter defines the upper bound of the random jitter added to every
+	// backoff interval.
+	//
+	// Optional. Default: 1 * time.Second
+	MaxJitter time.Duration
+
 	// currentInterval tracks the current waiting time.
 	//
 	// Optional. Default: 1 * time.Second
@@ -40,6 +46,7 @@ var DefaultConfig = Config{
 	MaxBackoffTime:  32 * time.Second,
 	Multiplier:      2.0,
 	MaxRetryCount:   10,
+	MaxJitter:       1 * time.Second,
 	currentInterval: 1 * time.Second,
 }
 
@@ -61,6 +68,9 @@ func configDefault(config ...Config) Config {
 	if cfg.MaxRetryCount <= 0 {
 		cfg.MaxRetryCount = DefaultConfig.MaxRetryCount
 	}
+	if cfg.MaxJitter <= 0 {
+		cfg.MaxJitter = DefaultConfig.MaxJitter
+	}
 	if cfg.currentInterval != cfg.InitialInterval {
 		cfg.currentInterval = DefaultConfig.currentInterval
 	}
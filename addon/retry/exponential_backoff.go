@@ -21,6 +21,10 @@ type ExponentialBackoff struct {
 	// MaxRetryCount is the maximum number of retry count.
 	MaxRetryCount int
 
+	// MaxJitter is the upper bound of the random jitter added to every interval.
+	// One second is used if it is not set.
+	MaxJitter time.Duration
+
 	// currentInterval tracks the current sleep time.
 	currentInterval time.Duration
 }
@@ -33,6 +37,7 @@ func NewExponentialBackoff(config ...Config) *ExponentialBackoff {
 		MaxBackoffTime:  cfg.MaxBackoffTime,
 		Multiplier:      cfg.Multiplier,
 		MaxRetryCount:   cfg.MaxRetryCount,
+		MaxJitter:       cfg.MaxJitter,
 		currentInterval: cfg.currentInterval,
 	}
 }
@@ -56,14 +61,28 @@ func (e *ExponentialBackoff) Retry(f func() error) error {
 	return err
 }
 
+// Next returns the time to wait before the next attempt and advances the
+// backoff. It allows callers to drive the attempts themselves, a backoff
+// without current interval starts with InitialInterval.
+func (e *ExponentialBackoff) Next() time.Duration {
+	if e.currentInterval <= 0 {
+		e.currentInterval = e.InitialInterval
+	}
+	return e.next()
+}
+
 // next calculates the next sleeping time interval.
 func (e *ExponentialBackoff) next() time.Duration {
-	// generate a random value between [0, 1000)
-	n, err := rand.Int(rand.Reader, big.NewInt(1000))
+	maxJitter := e.MaxJitter
+	if maxJitter <= 0 {
+		maxJitter = time.Second
+	}
+	// generate a random jitter between [0, maxJitter)
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(maxJitter)))
 	if err != nil {
 		return e.MaxBackoffTime
 	}
-	t := e.currentInterval + (time.Duration(n.Int64()) * time.Millisecond)
+	t := e.currentInterval + time.Duration(n.Int64())
 	e.currentInterval = time.Duration(float64(e.currentInterval) * e.Multiplier)
 	if t >= e.MaxBackoffTime {
 		e.currentInterval = e.MaxBackoffTime
@@ -121,3 +121,20 @@ func TestExponentialBackoff_Next(t *testing.T) {
 		})
 	}
 }
+
+func TestExponentialBackoff_NextJitter(t *testing.T) {
+	t.Parallel()
+	expBackoff := &ExponentialBackoff{
+		InitialInterval: 10 * time.Millisecond,
+		MaxBackoffTime:  40 * time.Millisecond,
+		Multiplier:      2.0,
+		MaxJitter:       time.Millisecond,
+	}
+
+	for _, expected := range []time.Duration{10, 20, 40, 40} {
+		expected *= time.Millisecond
+		next := expBackoff.Next()
+		require.GreaterOrEqual(t, next, expected)
+		require.LessOrEqual(t, next, expected+time.Millisecond)
+	}
+}
@@ -21,12 +21,14 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofiber/fiber/v3/log"
 	"github.com/gofiber/utils/v2"
 
 	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
 )
 
 // Version of current fiber package
@@ -89,10 +91,13 @@ type App struct {
 	mutex sync.Mutex
 	// Route stack divided by HTTP methods
 	stack [][]*Route
-	// Route stack divided by HTTP methods and route prefixes
-	treeStack []map[string][]*Route
+	// Route radix trees divided by HTTP methods, replaced as a whole when the routes change.
+	// It's a pointer, so copies of the app share the trees with the contexts of the app.
+	treeStack *atomic.Pointer[[]*routeTree]
 	// contains the information if the route stack has been changed to build the optimized tree
 	routesRefreshed bool
+	// contains the information if the tree was built by the startup process, later route changes are applied immediately
+	treeBuilt bool
 	// Amount of registered routes
 	routesCount uint32
 	// Amount of registered handlers
@@ -121,6 +126,8 @@ type App struct {
 	mountFields *mountFields
 	// Indicates if the value was explicitly configured
 	configured Config
+	// Prepares the server once for in-memory connections, see Client.App
+	inmemoryOnce sync.Once
 }
 
 // Config is a struct holding the server settings.
@@ -143,6 +150,28 @@ type Config struct {
 	// Default: false
 	CaseSensitive bool `json:"case_sensitive"`
 
+	// When set to true together with StrictRouting, requests without matching route are
+	// redirected to the path with or without trailing slash, if a route matches it.
+	// E.g. "/foo/" is redirected to "/foo". GET and HEAD requests are redirected with
+	// 301 Moved Permanently, other methods with 308 Permanent Redirect.
+	//
+	// Default: false
+	RedirectTrailingSlash bool `json:"redirect_trailing_slash"`
+
+	// When set to true together with CaseSensitive, requests without matching route are
+	// redirected to the path in the case of the route matching it case-insensitively.
+	// E.g. "/FoO" is redirected to "/foo".
+	//
+	// Default: false
+	RedirectFixedCase bool `json:"redirect_fixed_case"`
+
+	// When set to true, requests without matching route are redirected to the cleaned path,
+	// with collapsed slashes and resolved "." and ".." elements, if a route matches it.
+	// E.g. "/foo//bar/./baz" is redirected to "/foo/bar/baz".
+	//
+	// Default: false
+	RedirectCleanPath bool `json:"redirect_clean_path"`
+
 	// When set to true, this relinquishes the 0-allocation promise in certain
 	// cases in order to access the handler values (e.g. request bodies) in an
 	// immutable fashion so that these values are available even if you return
@@ -375,6 +404,20 @@ type Config struct {
 	// Optional. Default: DefaultMethods
 	RequestMethods []string
 
+	// CustomConstraints are user-defined route parameter constraints, which can be
+	// used in route patterns by their name like the built-in ones, e.g. "/:id<ulid>".
+	// They can also be added with App.RegisterCustomConstraint.
+	//
+	// Optional. Default: nil
+	CustomConstraints []CustomConstraint
+
+	// When set to true, OPTIONS requests to paths without an OPTIONS route are answered
+	// with 204 No Content and an Allow header listing the methods of the path, instead of
+	// 405 Method Not Allowed. Routes registered for OPTIONS still take precedence.
+	//
+	// Optional. Default: false
+	EnableAutoOptions bool `json:"enable_auto_options"`
+
 	// EnableSplittingOnParsers splits the query/body/header parameters by comma when it's true.
 	// For example, you can use it to parse multiple values from a query parameter like this:
 	//   /api?foo=bar,baz == foo[]=bar&foo[]=baz
@@ -545,6 +588,12 @@ func New(config ...Config) *App {
 	if app.config.XMLEncoder == nil {
 		app.config.XMLEncoder = xml.Marshal
 	}
+	customConstraints := app.config.CustomConstraints
+	app.config.CustomConstraints = make([]CustomConstraint, 0, len(customConstraints))
+	for _, custom := range customConstraints {
+		validateCustomConstraint(custom, app.config.CustomConstraints)
+		app.config.CustomConstraints = append(app.config.CustomConstraints, custom)
+	}
 	if len(app.config.RequestMethods) == 0 {
 		app.config.RequestMethods = DefaultMethods
 	}
@@ -556,7 +605,9 @@ func New(config ...Config) *App {
 
 	// Create router stack
 	app.stack = make([][]*Route, len(app.config.RequestMethods))
-	app.treeStack = make([]map[string][]*Route, len(app.config.RequestMethods))
+	treeStack := make([]*routeTree, len(app.config.RequestMethods))
+	app.treeStack = &atomic.Pointer[[]*routeTree]{}
+	app.treeStack.Store(&treeStack)
 
 	// Override colors
 	app.config.ColorScheme = defaultColors(app.config.ColorScheme)
@@ -602,25 +653,54 @@ func (app *App) SetTLSHandler(tlsHandler *TLSHandler) {
 	app.mutex.Unlock()
 }
 
+// RegisterCustomConstraint registers a user-defined route parameter constraint,
+// which can be used in the route patterns registered afterwards by its name.
+//
+//	app.RegisterCustomConstraint(ulidConstraint{})
+//	app.Get("/users/:id<ulid>", handler)
+func (app *App) RegisterCustomConstraint(constraint CustomConstraint) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	validateCustomConstraint(constraint, app.config.CustomConstraints)
+	app.config.CustomConstraints = append(app.config.CustomConstraints, constraint)
+}
+
 // Name Assign name to specific route.
 func (app *App) Name(name string) Router {
 	app.mutex.Lock()
 	defer app.mutex.Unlock()
 
-	for _, routes := range app.stack {
-		for _, route := range routes {
-			isMethodValid := route.Method == app.latestRoute.Method || app.latestRoute.use ||
-				(app.latestRoute.Method == MethodGet && route.Method == MethodHead)
-
-			if route.Path == app.latestRoute.Path && isMethodValid {
-				route.Name = name
-				if route.group != nil {
-					route.Name = route.group.name + route.Name
+	latest := app.latestRoute
+	for m, routes := range app.stack {
+		for i, route := range routes {
+			isMethodValid := route.Method == latest.Method || latest.use ||
+				(latest.Method == MethodGet && route.Method == MethodHead)
+
+			isSameDomain := route.domainPattern() == latest.domainPattern()
+			isSameConditions := route.sameConditions(latest)
+
+			if route.Path == latest.Path && isMethodValid && isSameDomain && isSameConditions {
+				// replace the route by a copy, it might be used by requests being served
+				named := *route
+				named.Name = name
+				if named.group != nil {
+					named.Name = named.group.name + named.Name
+				}
+				app.stack[m][i] = &named
+				app.routesRefreshed = true
+				if route == latest {
+					app.latestRoute = &named
 				}
 			}
 		}
 	}
 
+	// Serve the renamed routes right away if the app is already started
+	if app.treeBuilt {
+		app.buildTree()
+	}
+
 	if err := app.hooks.executeOnNameHooks(*app.latestRoute); err != nil {
 		panic(err)
 	}
@@ -659,6 +739,48 @@ func (app *App) GetRoutes(filterUseOption ...bool) []Route {
 	return rs
 }
 
+// RemoveRoute removes the routes registered on the path for the given methods,
+// or for all methods if none is given. Middleware registered with Use and
+// mounted sub-apps can't be removed. The routes registered on the path with
+// Domain or When are removed too, name them and use RemoveRouteByName to
+// remove only one of them.
+//
+// Routes can be removed while the app is serving requests, requests which
+// already started are completed with the previous routes.
+//
+//	app.RemoveRoute("/api/feature")
+//	app.RemoveRoute("/api/feature", fiber.MethodPost, fiber.MethodPut)
+func (app *App) RemoveRoute(path string, methods ...string) {
+	// Path always start with a '/'
+	if path == "" || path[0] != '/' {
+		path = "/" + path
+	}
+	// Prettify the path like registered routes
+	if !app.config.CaseSensitive {
+		path = utils.ToLower(path)
+	}
+	if !app.config.StrictRouting && len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+	path = RemoveEscapeChar(path)
+
+	app.removeRoutes(func(route *Route) bool {
+		return route.path == path
+	}, methods)
+}
+
+// RemoveRouteByName removes the routes with the given name for the given
+// methods, or for all methods if none is given. Like with RemoveRoute, named
+// middleware registered with Use and mounted sub-apps can't be removed.
+//
+// Routes can be removed while the app is serving requests, requests which
+// already started are completed with the previous routes.
+func (app *App) RemoveRouteByName(name string, methods ...string) {
+	app.removeRoutes(func(route *Route) bool {
+		return route.Name == name
+	}, methods)
+}
+
 // Use registers a middleware route that will match requests
 // with the provided prefix (which is optional and defaults to "/").
 // Also, you can pass another app instance as a sub-router along a routing path.
@@ -779,7 +901,7 @@ func (app *App) Add(methods []string, path string, handler Handler, middleware .
 
 // Static will create a file server serving static files
 func (app *App) Static(prefix, root string, config ...Static) Router {
-	app.registerStatic(prefix, root, config...)
+	app.registerStatic(prefix, root, nil, config...)
 
 	return app
 }
@@ -979,6 +1101,22 @@ func (app *App) Test(req *http.Request, msTimeout ...int) (*http.Response, error
 	return res, nil
 }
 
+// dialInmemory returns an in-memory connection served by the app in a new
+// goroutine, see Client.App.
+func (app *App) dialInmemory(_ string) (net.Conn, error) {
+	// prepare the server for the start, later route changes are applied immediately
+	app.inmemoryOnce.Do(func() {
+		app.startupProcess()
+	})
+
+	conns := fasthttputil.NewPipeConns()
+	go func() {
+		_ = app.server.ServeConn(conns.Conn2()) //nolint:errcheck // The client sees the closed connection
+	}()
+
+	return conns.Conn1(), nil
+}
+
 type disableLogger struct{}
 
 func (*disableLogger) Printf(_ string, _ ...any) {
@@ -1110,6 +1248,7 @@ func (app *App) startupProcess() *App {
 
 	// build route tree stack
 	app.buildTree()
+	app.treeBuilt = true
 
 	return app
 }
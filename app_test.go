@@ -19,6 +19,7 @@ import (
 	"reflect"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -107,6 +108,69 @@ func Test_App_MethodNotAllowed(t *testing.T) {
 	require.Equal(t, "GET, HEAD, POST, OPTIONS", resp.Header.Get(HeaderAllow))
 }
 
+// go test -run Test_App_AutoOptions
+func Test_App_AutoOptions(t *testing.T) {
+	t.Parallel()
+	app := New(Config{EnableAutoOptions: true})
+
+	app.Use(func(c Ctx) error {
+		c.Set("X-Middleware", "true")
+		return c.Next()
+	})
+	app.Get("/users", testEmptyHandler)
+	app.Post("/users", testEmptyHandler)
+	app.Put("/users/:id", testEmptyHandler)
+	app.Options("/explicit", func(c Ctx) error {
+		return c.SendStatus(StatusOK)
+	})
+	app.Delete("/explicit", testEmptyHandler)
+
+	resp, err := app.Test(httptest.NewRequest(MethodOptions, "/users", nil))
+	require.NoError(t, err)
+	require.Equal(t, StatusNoContent, resp.StatusCode)
+	require.Equal(t, "GET, HEAD, POST, OPTIONS", resp.Header.Get(HeaderAllow))
+	require.Equal(t, "true", resp.Header.Get("X-Middleware"))
+
+	resp, err = app.Test(httptest.NewRequest(MethodOptions, "/users/1", nil))
+	require.NoError(t, err)
+	require.Equal(t, StatusNoContent, resp.StatusCode)
+	require.Equal(t, "PUT, OPTIONS", resp.Header.Get(HeaderAllow))
+
+	resp, err = app.Test(httptest.NewRequest(MethodOptions, "/explicit", nil))
+	require.NoError(t, err)
+	require.Equal(t, StatusOK, resp.StatusCode)
+	require.Equal(t, "", resp.Header.Get(HeaderAllow))
+
+	resp, err = app.Test(httptest.NewRequest(MethodOptions, "/unknown", nil))
+	require.NoError(t, err)
+	require.Equal(t, StatusNotFound, resp.StatusCode)
+
+	// other methods are still rejected
+	resp, err = app.Test(httptest.NewRequest(MethodPatch, "/users", nil))
+	require.NoError(t, err)
+	require.Equal(t, StatusMethodNotAllowed, resp.StatusCode)
+	require.Equal(t, "GET, POST", resp.Header.Get(HeaderAllow))
+
+	// custom contexts
+	app.NewCtxFunc(func(app *App) CustomCtx {
+		return &customCtx{
+			DefaultCtx: *NewDefaultCtx(app),
+		}
+	})
+	resp, err = app.Test(httptest.NewRequest(MethodOptions, "/users", nil))
+	require.NoError(t, err)
+	require.Equal(t, StatusNoContent, resp.StatusCode)
+	require.Equal(t, "GET, HEAD, POST, OPTIONS", resp.Header.Get(HeaderAllow))
+
+	// disabled by default
+	app = New()
+	app.Get("/users", testEmptyHandler)
+	resp, err = app.Test(httptest.NewRequest(MethodOptions, "/users", nil))
+	require.NoError(t, err)
+	require.Equal(t, StatusMethodNotAllowed, resp.StatusCode)
+	require.Equal(t, "GET", resp.Header.Get(HeaderAllow))
+}
+
 func Test_App_Custom_Middleware_404_Should_Not_SetMethodNotAllowed(t *testing.T) {
 	t.Parallel()
 	app := New()
@@ -652,6 +716,164 @@ func Test_App_Order(t *testing.T) {
 	require.Equal(t, "123", string(body))
 }
 
+// go test -run Test_App_RegisterCustomConstraint
+func Test_App_RegisterCustomConstraint(t *testing.T) {
+	t.Parallel()
+	app := New(Config{CustomConstraints: []CustomConstraint{ulidConstraint{}}})
+	app.RegisterCustomConstraint(prefixConstraint{})
+
+	app.Get("/users/:id<ulid>", func(c Ctx) error {
+		return c.SendString("user " + c.Params("id"))
+	})
+	app.Get("/keys/:key<prefix(usr_,org_)>", func(c Ctx) error {
+		return c.SendString("key " + c.Params("key"))
+	})
+
+	// constraints of the app apply to the routes of mounted sub apps, which
+	// have to know them to register the routes though
+	sub := New(Config{CustomConstraints: []CustomConstraint{ulidConstraint{}}})
+	sub.RegisterCustomConstraint(evenConstraint{})
+	sub.Get("/:n<even>/:id<ulid>", func(c Ctx) error {
+		return c.SendString("even " + c.Params("n"))
+	})
+	app.Use("/sub", sub)
+
+	testCases := []struct {
+		target string
+		code   int
+		body   string
+	}{
+		{target: "/users/01ARZ3NDEKTSV4RRFFQ69G5FAV", code: StatusOK, body: "user 01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		{target: "/users/42", code: StatusNotFound},
+		{target: "/keys/usr_1", code: StatusOK, body: "key usr_1"},
+		{target: "/keys/grp_1", code: StatusNotFound},
+		{target: "/sub/42/01ARZ3NDEKTSV4RRFFQ69G5FAV", code: StatusOK, body: "even 42"},
+		{target: "/sub/41/01ARZ3NDEKTSV4RRFFQ69G5FAV", code: StatusNotFound},
+	}
+	for _, tc := range testCases {
+		resp, err := app.Test(httptest.NewRequest(MethodGet, tc.target, nil))
+		require.NoError(t, err, "app.Test(req)")
+		require.Equal(t, tc.code, resp.StatusCode, tc.target)
+		if tc.code == StatusOK {
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, tc.body, string(body), tc.target)
+		}
+	}
+
+	require.True(t, RoutePatternMatch("/users/01ARZ3NDEKTSV4RRFFQ69G5FAV", "/users/:id<ulid>", app.Config()))
+	require.False(t, RoutePatternMatch("/users/42", "/users/:id<ulid>", app.Config()))
+
+	require.PanicsWithValue(t, `route: custom constraint "ulid" is already registered`, func() {
+		app.RegisterCustomConstraint(ulidConstraint{})
+	})
+	require.PanicsWithValue(t, `route: custom constraint "int" conflicts with a built-in constraint`, func() {
+		app.RegisterCustomConstraint(namedConstraint("int"))
+	})
+	require.PanicsWithValue(t, `route: invalid custom constraint name "a<b"`, func() {
+		New(Config{CustomConstraints: []CustomConstraint{namedConstraint("a<b")}})
+	})
+	require.PanicsWithValue(t, "route: custom constraint must not be nil", func() {
+		app.RegisterCustomConstraint(nil)
+	})
+	require.PanicsWithValue(t, `route: invalid arguments for constraint "prefix": at least one prefix is required`, func() {
+		app.Get("/keys/:key<prefix()>", func(c Ctx) error {
+			return c.SendStatus(StatusOK)
+		})
+	})
+}
+
+// go test -run Test_App_CustomConstraint_Case
+func Test_App_CustomConstraint_Case(t *testing.T) {
+	t.Parallel()
+	app := New(Config{CustomConstraints: []CustomConstraint{isoDateConstraint{}, prefixConstraint{}}})
+
+	app.Get("/d/:date<isoDate>", func(c Ctx) error {
+		return c.SendString("date " + c.Params("date"))
+	})
+	app.Get("/u/:id<prefix(USR_)>", func(c Ctx) error {
+		return c.SendString("user " + c.Params("id"))
+	})
+	app.Get("/e/:date<ISODATE>", func(c Ctx) error {
+		return c.SendString("event " + c.Params("date"))
+	})
+	// hostnames are matched lowercased
+	app.Domain(":tenant<prefix(t)>.example.com").Get("/", func(c Ctx) error {
+		return c.SendString("tenant " + c.Params("tenant"))
+	})
+
+	testCases := []struct {
+		target string
+		code   int
+		body   string
+	}{
+		{target: "/d/2024-01-02", code: StatusOK, body: "date 2024-01-02"},
+		{target: "/d/notadate", code: StatusNotFound},
+		{target: "/U/USR_1", code: StatusOK, body: "user USR_1"},
+		{target: "/u/usr_1", code: StatusNotFound},
+		{target: "/e/2024-01-02", code: StatusOK, body: "event 2024-01-02"},
+		{target: "/e/notadate", code: StatusNotFound},
+		{target: "http://T1.example.com/", code: StatusOK, body: "tenant t1"},
+		{target: "http://x1.example.com/", code: StatusNotFound},
+	}
+	for _, tc := range testCases {
+		resp, err := app.Test(httptest.NewRequest(MethodGet, tc.target, nil))
+		require.NoError(t, err, "app.Test(req)")
+		require.Equal(t, tc.code, resp.StatusCode, tc.target)
+		if tc.code == StatusOK {
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, tc.body, string(body), tc.target)
+		}
+	}
+
+	// names are case-insensitive, unknown names are rejected
+	require.PanicsWithValue(t, `route: custom constraint "ISODATE" is already registered`, func() {
+		app.RegisterCustomConstraint(namedConstraint("ISODATE"))
+	})
+	require.PanicsWithValue(t, `route: custom constraint "Int" conflicts with a built-in constraint`, func() {
+		app.RegisterCustomConstraint(namedConstraint("Int"))
+	})
+	require.PanicsWithValue(t, `route: unknown constraint "isoDat"`, func() {
+		app.Get("/x/:date<isoDat>", testEmptyHandler)
+	})
+}
+
+// isoDateConstraint matches dates in the ISO 8601 format
+type isoDateConstraint struct{}
+
+func (isoDateConstraint) Name() string {
+	return "isoDate"
+}
+
+func (isoDateConstraint) Execute(param string, _ ...string) bool {
+	_, err := time.Parse("2006-01-02", param)
+	return err == nil
+}
+
+// evenConstraint matches even integers
+type evenConstraint struct{}
+
+func (evenConstraint) Name() string {
+	return "even"
+}
+
+func (evenConstraint) Execute(param string, _ ...string) bool {
+	n, err := strconv.Atoi(param)
+	return err == nil && n%2 == 0
+}
+
+// namedConstraint is a constraint with the given name, which matches all parameters
+type namedConstraint string
+
+func (n namedConstraint) Name() string {
+	return string(n)
+}
+
+func (namedConstraint) Execute(string, ...string) bool {
+	return true
+}
+
 func Test_App_Methods(t *testing.T) {
 	t.Parallel()
 	dummyHandler := testEmptyHandler
@@ -20,8 +20,9 @@ type StructValidator interface {
 
 // Bind struct
 type Bind struct {
-	ctx    *DefaultCtx
-	should bool
+	ctx            *DefaultCtx
+	should         bool
+	skipValidation bool // Validated once after binding all sources, see Typed
 }
 
 // To handle binder errors manually, you can prefer Should method.
@@ -42,7 +43,7 @@ func (b *Bind) Must() *Bind {
 
 // Check Should/Must errors and return it by usage.
 func (b *Bind) returnErr(err error) error {
-	if !b.should {
+	if err != nil && !b.should {
 		b.ctx.Status(StatusBadRequest)
 		return NewError(StatusBadRequest, "Bad request: "+err.Error())
 	}
@@ -53,7 +54,7 @@ func (b *Bind) returnErr(err error) error {
 // Struct validation.
 func (b *Bind) validateStruct(out any) error {
 	validator := b.ctx.app.config.StructValidator
-	if validator != nil {
+	if validator != nil && !b.skipValidation {
 		return validator.ValidateStruct(out)
 	}
 
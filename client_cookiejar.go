@@ -0,0 +1,278 @@
+package fiber
+
+import (
+	"bytes"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/utils/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// CookieJar stores the cookies of responses and provides the cookies to send
+// with requests.
+//
+// Implementations must be safe for concurrent use.
+type CookieJar interface {
+	// Cookies returns the cookies to send with a request to uri.
+	Cookies(uri *fasthttp.URI) []*fasthttp.Cookie
+
+	// SetCookies stores the cookies received in a response from uri. Cookies
+	// with a negative MaxAge or an expiry in the past delete the stored ones.
+	//
+	// The cookies must not be retained after returning.
+	SetCookies(uri *fasthttp.URI, cookies []*fasthttp.Cookie)
+}
+
+// MemoryCookieJar is an in-memory CookieJar implementing the domain, path
+// and expiry matching of RFC 6265.
+//
+// Public suffixes are not known to the jar, it only rejects cookies for
+// top-level domains.
+type MemoryCookieJar struct {
+	mu sync.Mutex
+	// entries holds the cookies by domain and by name and path
+	entries map[string]map[string]*jarEntry
+	// seq orders cookies with the same path length by creation
+	seq uint64
+}
+
+type jarEntry struct {
+	expires  time.Time
+	name     string
+	value    string
+	path     string
+	seq      uint64
+	hostOnly bool
+	secure   bool
+}
+
+// NewCookieJar creates an empty in-memory CookieJar.
+func NewCookieJar() *MemoryCookieJar {
+	return &MemoryCookieJar{
+		entries: make(map[string]map[string]*jarEntry),
+	}
+}
+
+// Cookies implements CookieJar.
+func (j *MemoryCookieJar) Cookies(uri *fasthttp.URI) []*fasthttp.Cookie {
+	host := canonicalCookieHost(uri.Host())
+	if host == "" {
+		return nil
+	}
+	path := string(uri.Path())
+	secure := bytes.Equal(uri.Scheme(), []byte(schemeHTTPS))
+	now := time.Now()
+
+	isIP := net.ParseIP(host) != nil
+
+	j.mu.Lock()
+	var matched []*jarEntry
+	// Visit the host and all of its parent domains
+	for domain := host; ; {
+		for key, e := range j.entries[domain] {
+			switch {
+			case !e.expires.IsZero() && !e.expires.After(now):
+				delete(j.entries[domain], key)
+			case e.hostOnly && domain != host,
+				e.secure && !secure,
+				!cookiePathMatch(path, e.path):
+			default:
+				matched = append(matched, e)
+			}
+		}
+
+		i := strings.IndexByte(domain, '.')
+		if i < 0 || isIP {
+			break
+		}
+		domain = domain[i+1:]
+	}
+	j.mu.Unlock()
+
+	// Longer paths first, then the oldest cookies first
+	sort.Slice(matched, func(a, b int) bool {
+		if len(matched[a].path) != len(matched[b].path) {
+			return len(matched[a].path) > len(matched[b].path)
+		}
+		return matched[a].seq < matched[b].seq
+	})
+
+	cookies := make([]*fasthttp.Cookie, len(matched))
+	for i, e := range matched {
+		cookies[i] = &fasthttp.Cookie{}
+		cookies[i].SetKey(e.name)
+		cookies[i].SetValue(e.value)
+	}
+
+	return cookies
+}
+
+// SetCookies implements CookieJar.
+func (j *MemoryCookieJar) SetCookies(uri *fasthttp.URI, cookies []*fasthttp.Cookie) {
+	host := canonicalCookieHost(uri.Host())
+	if host == "" {
+		return
+	}
+	now := time.Now()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, c := range cookies {
+		if len(c.Key()) == 0 {
+			continue
+		}
+
+		domain, hostOnly := host, true
+		if d := strings.TrimPrefix(utils.ToLower(string(c.Domain())), "."); d != "" && d != host {
+			// The host must domain-match the attribute, which must not be an IP
+			// address or a top-level domain
+			if !strings.HasSuffix(host, "."+d) || net.ParseIP(host) != nil || !strings.Contains(d, ".") {
+				continue
+			}
+			domain, hostOnly = d, false
+		} else if d != "" {
+			hostOnly = false
+		}
+
+		path := string(c.Path())
+		if path == "" || path[0] != '/' {
+			path = defaultCookiePath(string(uri.Path()))
+		}
+
+		var expires time.Time
+		if maxAge := c.MaxAge(); maxAge < 0 {
+			expires = now
+		} else if maxAge > 0 {
+			expires = now.Add(time.Duration(maxAge) * time.Second)
+		} else if exp := c.Expire(); !exp.Equal(fasthttp.CookieExpireUnlimited) {
+			expires = exp
+		}
+
+		key := string(c.Key()) + ";" + path
+		if !expires.IsZero() && !expires.After(now) {
+			delete(j.entries[domain], key)
+			continue
+		}
+
+		entries := j.entries[domain]
+		if entries == nil {
+			entries = make(map[string]*jarEntry)
+			j.entries[domain] = entries
+		}
+
+		e := &jarEntry{
+			name:     string(c.Key()),
+			value:    string(c.Value()),
+			path:     path,
+			expires:  expires,
+			hostOnly: hostOnly,
+			secure:   c.Secure(),
+		}
+		// Keep the creation order of replaced cookies
+		if old, ok := entries[key]; ok {
+			e.seq = old.seq
+		} else {
+			j.seq++
+			e.seq = j.seq
+		}
+		entries[key] = e
+	}
+}
+
+// canonicalCookieHost returns the lowercase host without port.
+func canonicalCookieHost(host []byte) string {
+	h := string(host)
+	if hostname, _, err := net.SplitHostPort(h); err == nil {
+		h = hostname
+	}
+	return utils.ToLower(strings.TrimSuffix(h, "."))
+}
+
+// defaultCookiePath returns the default path of a cookie as defined in
+// RFC 6265 section 5.1.4.
+func defaultCookiePath(path string) string {
+	i := strings.LastIndexByte(path, '/')
+	if path == "" || path[0] != '/' || i == 0 {
+		return "/"
+	}
+	return path[:i]
+}
+
+// cookiePathMatch reports whether the request path path-matches the cookie
+// path as defined in RFC 6265 section 5.1.4.
+func cookiePathMatch(path, cookiePath string) bool {
+	if path == "" {
+		path = "/"
+	}
+	if !strings.HasPrefix(path, cookiePath) {
+		return false
+	}
+	return len(path) == len(cookiePath) ||
+		cookiePath[len(cookiePath)-1] == '/' ||
+		path[len(cookiePath)] == '/'
+}
+
+// setJarCookies adds the cookies of jar for the request URI to req, keeping
+// the cookies which are already set on the request.
+func setJarCookies(jar CookieJar, req *Request) {
+	for _, c := range jar.Cookies(req.URI()) {
+		if len(req.Header.CookieBytes(c.Key())) == 0 {
+			req.Header.SetCookieBytesKV(c.Key(), c.Value())
+		}
+	}
+}
+
+// storeJarCookies stores the cookies set by resp in jar.
+func storeJarCookies(jar CookieJar, uri *fasthttp.URI, resp *Response) {
+	var cookies []*fasthttp.Cookie
+	resp.Header.VisitAllCookie(func(_, value []byte) {
+		// fasthttp rejects negative Max-Age values and doesn't distinguish
+		// Max-Age=0 from a missing attribute
+		value, expired := cutExpiredMaxAge(value)
+		c := fasthttp.AcquireCookie()
+		if err := c.ParseBytes(value); err != nil {
+			fasthttp.ReleaseCookie(c)
+			return
+		}
+		if expired {
+			c.SetMaxAge(-1)
+		}
+		cookies = append(cookies, c)
+	})
+	if len(cookies) == 0 {
+		return
+	}
+
+	jar.SetCookies(uri, cookies)
+	for _, c := range cookies {
+		fasthttp.ReleaseCookie(c)
+	}
+}
+
+// cutExpiredMaxAge removes the Max-Age attributes of zero or less from the
+// Set-Cookie value and reports whether there were any. Such a Max-Age expires
+// the cookie immediately, see RFC 6265 section 5.2.2.
+func cutExpiredMaxAge(value []byte) ([]byte, bool) {
+	attrs := bytes.Split(value, []byte{';'})
+	n := 0
+	for _, attr := range attrs {
+		k, v, ok := bytes.Cut(bytes.TrimSpace(attr), []byte{'='})
+		if ok && utils.EqualFold(utils.UnsafeString(k), "max-age") {
+			if age, err := strconv.Atoi(string(bytes.TrimSpace(v))); err == nil && age <= 0 {
+				continue
+			}
+		}
+		attrs[n] = attr
+		n++
+	}
+	if n == len(attrs) {
+		return value, false
+	}
+	return bytes.Join(attrs[:n], []byte{';'}), true
+}
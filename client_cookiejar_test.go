@@ -0,0 +1,197 @@
+package fiber
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func testJarURI(t *testing.T, rawURI string) *fasthttp.URI {
+	t.Helper()
+
+	uri := fasthttp.AcquireURI()
+	t.Cleanup(func() { fasthttp.ReleaseURI(uri) })
+	require.NoError(t, uri.Parse(nil, []byte(rawURI)))
+
+	return uri
+}
+
+func testJarSet(t *testing.T, jar CookieJar, rawURI string, setCookies ...string) {
+	t.Helper()
+
+	cookies := make([]*fasthttp.Cookie, len(setCookies))
+	for i, v := range setCookies {
+		cookies[i] = &fasthttp.Cookie{}
+		require.NoError(t, cookies[i].Parse(v))
+	}
+	jar.SetCookies(testJarURI(t, rawURI), cookies)
+}
+
+func testJarGet(t *testing.T, jar CookieJar, rawURI string) []string {
+	t.Helper()
+
+	var result []string
+	for _, c := range jar.Cookies(testJarURI(t, rawURI)) {
+		result = append(result, string(c.Key())+"="+string(c.Value()))
+	}
+
+	return result
+}
+
+// go test -run Test_MemoryCookieJar_Domain
+func Test_MemoryCookieJar_Domain(t *testing.T) {
+	t.Parallel()
+
+	jar := NewCookieJar()
+	testJarSet(t, jar, "http://www.example.com/",
+		"host=1",
+		"domain=2; Domain=example.com",
+		"dotted=3; Domain=.www.example.com",
+		"foreign=4; Domain=other.com",
+		"tld=5; Domain=com",
+	)
+
+	require.Equal(t, []string{"host=1", "domain=2", "dotted=3"}, testJarGet(t, jar, "http://www.example.com/"))
+	require.Equal(t, []string{"domain=2", "dotted=3"}, testJarGet(t, jar, "http://api.www.example.com/"))
+	require.Equal(t, []string{"domain=2"}, testJarGet(t, jar, "http://EXAMPLE.com:8080/"))
+	require.Empty(t, testJarGet(t, jar, "http://other.com/"))
+	require.Empty(t, testJarGet(t, jar, "http://notexample.com/"))
+}
+
+// go test -run Test_MemoryCookieJar_Path
+func Test_MemoryCookieJar_Path(t *testing.T) {
+	t.Parallel()
+
+	jar := NewCookieJar()
+	testJarSet(t, jar, "http://example.com/api/v1/users",
+		"root=1; Path=/",
+		"default=2",
+		"api=3; Path=/api",
+	)
+
+	require.Equal(t, []string{"default=2", "api=3", "root=1"}, testJarGet(t, jar, "http://example.com/api/v1/users"))
+	require.Equal(t, []string{"api=3", "root=1"}, testJarGet(t, jar, "http://example.com/api"))
+	require.Equal(t, []string{"root=1"}, testJarGet(t, jar, "http://example.com/apiv2"))
+	require.Equal(t, []string{"root=1"}, testJarGet(t, jar, "http://example.com/"))
+}
+
+// go test -run Test_MemoryCookieJar_Expiry
+func Test_MemoryCookieJar_Expiry(t *testing.T) {
+	t.Parallel()
+
+	jar := NewCookieJar()
+	testJarSet(t, jar, "https://example.com/",
+		"session=1",
+		"persistent=2; Max-Age=3600",
+		"expired=3; Expires="+time.Now().Add(-time.Hour).UTC().Format(time.RFC1123),
+		"secure=4; Secure",
+	)
+
+	require.Equal(t, []string{"session=1", "persistent=2", "secure=4"}, testJarGet(t, jar, "https://example.com/"))
+	require.Equal(t, []string{"session=1", "persistent=2"}, testJarGet(t, jar, "http://example.com/"))
+
+	// Replacing keeps the order, expiring deletes
+	testJarSet(t, jar, "https://example.com/", "session=5")
+	jar.SetCookies(testJarURI(t, "https://example.com/"), []*fasthttp.Cookie{func() *fasthttp.Cookie {
+		c := &fasthttp.Cookie{}
+		c.SetKey("persistent")
+		c.SetExpire(fasthttp.CookieExpireDelete)
+		return c
+	}()})
+
+	require.Equal(t, []string{"session=5", "secure=4"}, testJarGet(t, jar, "https://example.com/"))
+}
+
+// go test -run Test_MemoryCookieJar_MaxAge
+func Test_MemoryCookieJar_MaxAge(t *testing.T) {
+	t.Parallel()
+
+	jar := NewCookieJar()
+	testJarSet(t, jar, "https://example.com/", "a=1", "b=2", "c=3", "d=4")
+
+	resp := AcquireResponse()
+	defer ReleaseResponse(resp)
+	resp.Header.Add(HeaderSetCookie, "a=; Max-Age=0")
+	resp.Header.Add(HeaderSetCookie, "b=; Max-Age=-1")
+	resp.Header.Add(HeaderSetCookie, "c=5; Max-Age=60")
+	storeJarCookies(jar, testJarURI(t, "https://example.com/"), resp)
+
+	require.Equal(t, []string{"c=5", "d=4"}, testJarGet(t, jar, "https://example.com/"))
+
+	// A negative MaxAge set on the cookie deletes it as well
+	c := &fasthttp.Cookie{}
+	c.SetKey("d")
+	c.SetMaxAge(-1)
+	jar.SetCookies(testJarURI(t, "https://example.com/"), []*fasthttp.Cookie{c})
+
+	require.Equal(t, []string{"c=5"}, testJarGet(t, jar, "https://example.com/"))
+}
+
+// go test -run Test_Client_CookieJar
+func Test_Client_CookieJar(t *testing.T) {
+	t.Parallel()
+
+	ln := fasthttputil.NewInmemoryListener()
+
+	app := New()
+
+	app.Get("/login", func(c Ctx) error {
+		c.Cookie(&Cookie{Name: "session", Value: "secret"})
+		return c.Redirect().To("/profile")
+	})
+	app.Get("/profile", func(c Ctx) error {
+		if c.Cookies("session") != "secret" {
+			return c.SendStatus(StatusUnauthorized)
+		}
+		return c.SendString("profile " + c.Cookies("lang"))
+	})
+	app.Get("/loop", func(c Ctx) error {
+		return c.Redirect().To("/loop")
+	})
+	app.Get("/logout", func(c Ctx) error {
+		c.ClearCookie("session")
+		return c.SendStatus(StatusNoContent)
+	})
+
+	go func() {
+		require.Nil(t, app.Listener(ln, ListenConfig{
+			DisableStartupMessage: true,
+		}))
+	}()
+
+	jar := NewCookieJar()
+	c := &Client{
+		CookieJar: jar,
+		Dial:      func(addr string) (net.Conn, error) { return ln.Dial() },
+	}
+
+	// The cookie is stored before the redirect is followed
+	code, body, errs := c.Get("http://example.com/login").MaxRedirectsCount(1).Cookie("lang", "en").String()
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, StatusOK, code)
+	require.Equal(t, "profile en", body)
+
+	code, body, errs = c.Get("http://example.com/profile").String()
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, StatusOK, code)
+	require.Equal(t, "profile ", body)
+
+	// The jar is not sent to other hosts
+	code, _, errs = c.Get("http://other.example.org/profile").String()
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, StatusUnauthorized, code)
+
+	code, _, errs = c.Get("http://example.com/logout").String()
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, StatusNoContent, code)
+	require.Empty(t, testJarGet(t, jar, "http://example.com/profile"))
+
+	// Redirects are limited
+	_, _, errs = c.Get("http://example.com/loop").MaxRedirectsCount(2).String()
+	require.Equal(t, 1, len(errs))
+	require.ErrorIs(t, errs[0], fasthttp.ErrTooManyRedirects)
+}
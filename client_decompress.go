@@ -0,0 +1,188 @@
+package fiber
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gofiber/utils/v2"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// acceptEncoding is sent by agents negotiating compressed responses
+	acceptEncoding = "gzip, deflate, br"
+
+	// defaultMaxDecompressedBodySize is used if the Client doesn't limit the
+	// size of decompressed bodies
+	defaultMaxDecompressedBodySize = 32 * 1024 * 1024
+)
+
+// DisableDecompression disables the Accept-Encoding negotiation and the
+// transparent decompression of the response body.
+func (a *Agent) DisableDecompression() *Agent {
+	a.disableDecompression = true
+
+	return a
+}
+
+// MaxDecompressedBodySize limits the size of decompressed response bodies.
+func (a *Agent) MaxDecompressedBodySize(size int) *Agent {
+	a.maxDecompressedBodySize = size
+
+	return a
+}
+
+// sendDecompressed sends the request with the Accept-Encoding header and
+// decodes the response body. Responses are only decoded if the header wasn't
+// set by the user, who must handle the encoding then.
+func (s *requestSender) sendDecompressed(req *Request, resp *Response) error {
+	if len(req.Header.Peek(HeaderAcceptEncoding)) > 0 {
+		return s.sendRaw(req, resp)
+	}
+	req.Header.Set(HeaderAcceptEncoding, acceptEncoding)
+	defer req.Header.Del(HeaderAcceptEncoding)
+
+	if !resp.StreamBody {
+		if err := s.sendRaw(req, resp); err != nil {
+			return err
+		}
+		return decompressBody(resp, s.maxDecompressedSize)
+	}
+
+	// The body stream can't be replaced without closing it, so the response
+	// is read into a copy keeping the stream bound to the connection
+	raw := AcquireResponse()
+	raw.StreamBody = true
+	err := s.sendRaw(req, raw)
+	raw.CopyTo(resp)
+
+	stream := raw.BodyStream()
+	if stream == nil {
+		ReleaseResponse(raw)
+		if err != nil {
+			return err
+		}
+		return decompressBody(resp, s.maxDecompressedSize)
+	}
+
+	body, err := decompressStream(resp, stream, s.maxDecompressedSize)
+	resp.SetBodyStream(&streamReader{Reader: body, close: func() error {
+		defer ReleaseResponse(raw)
+		return raw.CloseBodyStream()
+	}}, resp.Header.ContentLength())
+
+	return err
+}
+
+// decompressBody replaces the buffered body of resp with its decoded content
+// and removes the Content-Encoding header. Bodies with an unsupported
+// encoding are left as is.
+func decompressBody(resp *Response, limit int) error {
+	if resp.BodyStream() != nil {
+		return nil
+	}
+	body := resp.Body()
+	if len(body) == 0 {
+		return nil
+	}
+
+	r, err := decodingReader(resp.Header.ContentEncoding(), bytes.NewReader(body))
+	if err != nil || r == nil {
+		return err
+	}
+	defer r.Close() //nolint:errcheck // The read error is more relevant
+
+	decoded, err := io.ReadAll(&limitedReader{r: r, n: limit})
+	if err != nil {
+		return err
+	}
+
+	resp.Header.Del(HeaderContentEncoding)
+	resp.SetBodyRaw(decoded)
+	resp.Header.SetContentLength(len(decoded))
+
+	return nil
+}
+
+// decompressStream wraps the body stream of resp with a reader decoding its
+// content and removes the Content-Encoding header. The length of the decoded
+// body is unknown. Bodies with an unsupported encoding are left as is.
+func decompressStream(resp *Response, body io.Reader, limit int) (io.Reader, error) {
+	r, err := decodingReader(resp.Header.ContentEncoding(), body)
+	if err != nil || r == nil {
+		return body, err
+	}
+
+	resp.Header.Del(HeaderContentEncoding)
+	resp.Header.SetContentLength(-1)
+
+	return &limitedReader{r: r, n: limit}, nil
+}
+
+// decodingReader returns a reader decoding r according to the content
+// encoding, or nil if the encoding is not supported.
+func decodingReader(encoding []byte, r io.Reader) (io.ReadCloser, error) {
+	var (
+		dr  io.ReadCloser
+		err error
+	)
+	switch utils.ToLower(strings.TrimSpace(string(encoding))) {
+	case "gzip", "x-gzip":
+		dr, err = gzip.NewReader(r)
+	case "br":
+		dr = io.NopCloser(brotli.NewReader(r))
+	case "deflate":
+		dr, err = deflateReader(r)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fiber: failed to decompress response body: %w", err)
+	}
+
+	return dr, nil
+}
+
+// deflateReader returns a reader for zlib wrapped deflate data as defined
+// in RFC 9110, falling back to raw deflate sent by some servers.
+func deflateReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Wrapped by decodingReader
+	}
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(br) //nolint:wrapcheck // Wrapped by decodingReader
+	}
+
+	return flate.NewReader(br), nil
+}
+
+// limitedReader fails with fasthttp.ErrBodyTooLarge once more than n bytes
+// are read, protecting against decompression bombs.
+type limitedReader struct {
+	r io.Reader
+	n int
+}
+
+// Read implements io.Reader.
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n -= n
+	if l.n < 0 {
+		return n + l.n, fasthttp.ErrBodyTooLarge
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, fmt.Errorf("fiber: failed to decompress response body: %w", err)
+	}
+
+	return n, err //nolint:wrapcheck // io.EOF must not be wrapped
+}
@@ -0,0 +1,183 @@
+package fiber
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func testCompress(t *testing.T, encoding string, data []byte) []byte {
+	t.Helper()
+
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+		err error
+	)
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "br":
+		w = brotli.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		w, err = flate.NewWriter(&buf, flate.DefaultCompression)
+		require.NoError(t, err)
+	}
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func testDecompressServer(t *testing.T) *fasthttputil.InmemoryListener {
+	t.Helper()
+
+	payload := []byte(`{"success":true}`)
+
+	app := New()
+	for _, encoding := range []string{"gzip", "br", "deflate", "raw-deflate"} {
+		encoded := testCompress(t, encoding, payload)
+		contentEncoding := strings.TrimPrefix(encoding, "raw-")
+		app.Get("/"+encoding, func(c Ctx) error {
+			c.Set(HeaderContentEncoding, contentEncoding)
+			c.Set(HeaderContentType, MIMEApplicationJSON)
+			return c.Send(encoded)
+		})
+	}
+	app.Get("/accept-encoding", func(c Ctx) error {
+		return c.SendString(c.Get(HeaderAcceptEncoding))
+	})
+	app.Get("/bomb", func(c Ctx) error {
+		c.Set(HeaderContentEncoding, "gzip")
+		return c.Send(testCompress(t, "gzip", make([]byte, 1024*1024)))
+	})
+	app.Get("/stream", func(c Ctx) error {
+		c.Set(HeaderContentEncoding, "gzip")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			zw := gzip.NewWriter(w)
+			for i := 0; i < 1000; i++ {
+				_, _ = zw.Write([]byte("line\n")) //nolint:errcheck // We're in a test
+			}
+			_ = zw.Close() //nolint:errcheck // We're in a test
+		})
+		return nil
+	})
+
+	return testClientServer(t, app, nil)
+}
+
+// go test -run Test_Client_Agent_Decompression
+func Test_Client_Agent_Decompression(t *testing.T) {
+	t.Parallel()
+
+	ln := testDecompressServer(t)
+	c := &Client{
+		MaxDecompressedBodySize: 64 * 1024,
+		Dial:                    func(addr string) (net.Conn, error) { return ln.Dial() },
+	}
+
+	t.Run("encodings", func(t *testing.T) {
+		t.Parallel()
+
+		for _, encoding := range []string{"gzip", "br", "deflate", "raw-deflate"} {
+			var d data
+			code, body, errs := c.Get("http://example.com/" + encoding).Struct(&d)
+			require.Equal(t, 0, len(errs), encoding)
+			require.Equal(t, StatusOK, code)
+			require.Equal(t, `{"success":true}`, string(body), encoding)
+			require.True(t, d.Success)
+		}
+	})
+
+	t.Run("response headers", func(t *testing.T) {
+		t.Parallel()
+
+		a := c.Get("http://example.com/gzip")
+		resp := AcquireResponse()
+		defer ReleaseResponse(resp)
+		code, _, errs := a.SetResponse(resp).Bytes()
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, StatusOK, code)
+		require.Empty(t, resp.Header.Peek(HeaderContentEncoding))
+		require.Equal(t, len(`{"success":true}`), resp.Header.ContentLength())
+	})
+
+	t.Run("accept-encoding", func(t *testing.T) {
+		t.Parallel()
+
+		_, body, errs := c.Get("http://example.com/accept-encoding").String()
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, "gzip, deflate, br", body)
+
+		_, body, errs = c.Get("http://example.com/accept-encoding").DisableDecompression().String()
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, "", body)
+	})
+
+	t.Run("accept-encoding set by user", func(t *testing.T) {
+		t.Parallel()
+
+		_, body, errs := c.Get("http://example.com/gzip").Set(HeaderAcceptEncoding, "gzip").Bytes()
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, testCompress(t, "gzip", []byte(`{"success":true}`)), body)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		disabled := &Client{
+			DisableDecompression: true,
+			Dial:                 func(addr string) (net.Conn, error) { return ln.Dial() },
+		}
+		_, body, errs := disabled.Get("http://example.com/br").Bytes()
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, testCompress(t, "br", []byte(`{"success":true}`)), body)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, errs := c.Get("http://example.com/bomb").Bytes()
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], fasthttp.ErrBodyTooLarge)
+
+		_, body, errs := c.Get("http://example.com/bomb").MaxDecompressedBodySize(2 * 1024 * 1024).Bytes()
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, 1024*1024, len(body))
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		t.Parallel()
+
+		resp, errs := c.Get("http://example.com/stream").Stream()
+		require.Equal(t, 0, len(errs))
+		require.Empty(t, resp.Header().Peek(HeaderContentEncoding))
+		require.Equal(t, -1, resp.ContentLength())
+
+		body, err := io.ReadAll(resp)
+		require.NoError(t, err)
+		require.Equal(t, strings.Repeat("line\n", 1000), string(body))
+		require.NoError(t, resp.Close())
+
+		resp, errs = c.Get("http://example.com/stream").MaxDecompressedBodySize(1024).Stream()
+		require.Equal(t, 0, len(errs))
+
+		_, err = io.ReadAll(resp)
+		require.ErrorIs(t, err, fasthttp.ErrBodyTooLarge)
+		require.NoError(t, resp.Close())
+	})
+}
@@ -0,0 +1,408 @@
+package fiber
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/utils/v2"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	schemeSOCKS5 = "socks5"
+
+	socks5Version    = 0x05
+	socks5AuthNone   = 0x00
+	socks5AuthPasswd = 0x02
+	socks5CmdConnect = 0x01
+	socks5AtypIPv4   = 0x01
+	socks5AtypDomain = 0x03
+	socks5AtypIPv6   = 0x04
+
+	maxProxyResponseHeaderSize = 8 * 1024
+)
+
+var (
+	errProxyScheme = errors.New("fiber: unsupported proxy scheme, http, https and socks5 are supported")
+	errSOCKS5Auth  = errors.New("fiber: socks5 proxy rejected the authentication methods")
+)
+
+// proxyURL returns the proxy to use for requests to addr, or nil if the
+// host must be dialed directly.
+func (c *Client) proxyURL(addr string, isTLS bool) (*url.URL, error) {
+	raw := c.Proxy
+	if raw == "" && c.ProxyFromEnvironment {
+		if useProxy(addr, getEnvAny("NO_PROXY", "no_proxy")) {
+			if isTLS {
+				raw = getEnvAny("HTTPS_PROXY", "https_proxy")
+			} else {
+				raw = getEnvAny("HTTP_PROXY", "http_proxy")
+			}
+		}
+	}
+	if raw == "" {
+		return nil, nil //nolint:nilnil // No proxy is not an error
+	}
+
+	if !strings.Contains(raw, "://") {
+		raw = schemeHTTP + "://" + raw
+	}
+	proxy, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("fiber: invalid proxy url: %w", err)
+	}
+	switch proxy.Scheme {
+	case schemeHTTP, schemeHTTPS, schemeSOCKS5:
+	default:
+		return nil, errProxyScheme
+	}
+
+	return proxy, nil
+}
+
+// proxyDialer returns a fasthttp.DialFunc connecting to addr through proxy.
+//
+// HTTPS targets of HTTP proxies are tunneled with CONNECT, while plain HTTP
+// requests are sent to the proxy with an absolute request target. HTTPS
+// proxies are connected to with TLS using tlsConfig, verified against the
+// host of the proxy.
+func proxyDialer(proxy *url.URL, dial fasthttp.DialFunc, tlsConfig *tls.Config, isTLS bool) fasthttp.DialFunc {
+	if dial == nil {
+		dial = fasthttp.Dial
+	}
+	proxyAddr := proxy.Host
+	if proxy.Port() == "" {
+		port := "80"
+		switch proxy.Scheme {
+		case schemeHTTPS:
+			port = "443"
+		case schemeSOCKS5:
+			port = "1080"
+		}
+		proxyAddr = net.JoinHostPort(proxy.Hostname(), port)
+	}
+
+	var proxyTLSConfig *tls.Config
+	if proxy.Scheme == schemeHTTPS {
+		if tlsConfig != nil {
+			proxyTLSConfig = tlsConfig.Clone()
+		} else {
+			proxyTLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		proxyTLSConfig.ServerName = proxy.Hostname()
+	}
+
+	var auth string
+	if proxy.User != nil {
+		password, _ := proxy.User.Password()
+		auth = "Basic " + base64.StdEncoding.EncodeToString([]byte(proxy.User.Username()+":"+password))
+	}
+
+	return func(addr string) (net.Conn, error) {
+		conn, err := dial(proxyAddr)
+		if err != nil {
+			return nil, err
+		}
+		if proxyTLSConfig != nil {
+			tlsConn := tls.Client(conn, proxyTLSConfig)
+			if err = tlsConn.Handshake(); err != nil {
+				_ = conn.Close() //nolint:errcheck // The handshake error is more relevant
+				return nil, fmt.Errorf("fiber: failed to connect to proxy with tls: %w", err)
+			}
+			conn = &proxyTLSConn{Conn: tlsConn}
+		}
+
+		switch {
+		case proxy.Scheme == schemeSOCKS5:
+			err = socks5Connect(conn, addr, proxy.User)
+		case isTLS:
+			err = httpConnect(conn, addr, auth)
+		default:
+			return &httpProxyConn{Conn: conn, target: addr, auth: auth, requestStart: true}, nil
+		}
+		if err != nil {
+			_ = conn.Close() //nolint:errcheck // The handshake error is more relevant
+			return nil, err
+		}
+
+		return conn, nil
+	}
+}
+
+// proxyTLSConn is the TLS connection to an HTTPS proxy. It hides the
+// *tls.Conn from fasthttp, which would skip the TLS handshake with HTTPS
+// targets otherwise.
+type proxyTLSConn struct {
+	net.Conn
+}
+
+// httpConnect establishes a tunnel to addr with the CONNECT method.
+func httpConnect(conn net.Conn, addr, auth string) error {
+	req := "CONNECT " + addr + " HTTP/1.1\r\nHost: " + addr + "\r\n"
+	if auth != "" {
+		req += HeaderProxyAuthorization + ": " + auth + "\r\n"
+	}
+	if _, err := conn.Write([]byte(req + "\r\n")); err != nil {
+		return fmt.Errorf("fiber: failed to write proxy request: %w", err)
+	}
+
+	// The headers are read byte by byte, so nothing sent by the target after
+	// the tunnel is established is consumed.
+	var buf []byte
+	b := make([]byte, 1)
+	for !bytes.HasSuffix(buf, []byte("\r\n\r\n")) {
+		if len(buf) >= maxProxyResponseHeaderSize {
+			return errors.New("fiber: proxy response headers too large")
+		}
+		if _, err := io.ReadFull(conn, b); err != nil {
+			return fmt.Errorf("fiber: failed to read proxy response: %w", err)
+		}
+		buf = append(buf, b[0])
+	}
+
+	var header fasthttp.ResponseHeader
+	if err := header.Read(bufio.NewReader(bytes.NewReader(buf))); err != nil {
+		return fmt.Errorf("fiber: failed to read proxy response: %w", err)
+	}
+	if header.StatusCode() != StatusOK {
+		return fmt.Errorf("fiber: proxy refused connection to %s with status %d", addr, header.StatusCode())
+	}
+
+	return nil
+}
+
+// httpProxyConn sends plain HTTP requests to a proxy by rewriting the request
+// line of every request to the absolute form and adding Proxy-Authorization.
+//
+// A request ends when its response is read, since the HostClient never
+// pipelines requests on a connection.
+type httpProxyConn struct {
+	net.Conn
+	target       string
+	auth         string
+	pending      []byte
+	requestStart bool
+}
+
+// Write implements net.Conn.
+func (c *httpProxyConn) Write(p []byte) (int, error) {
+	if !c.requestStart {
+		return c.Conn.Write(p) //nolint:wrapcheck // This must not be wrapped
+	}
+
+	// Wait for the complete request line
+	c.pending = append(c.pending, p...)
+	i := bytes.Index(c.pending, []byte("\r\n"))
+	if i < 0 {
+		return len(p), nil
+	}
+	c.requestStart = false
+
+	line := c.pending[:i]
+	buf := make([]byte, 0, len(c.pending)+len(c.target)+len(c.auth)+32)
+	if method, target, ok := bytes.Cut(line, []byte{' '}); ok && len(target) > 0 && target[0] == '/' {
+		buf = append(buf, method...)
+		buf = append(buf, " http://"...)
+		buf = append(buf, c.target...)
+		buf = append(buf, target...)
+	} else {
+		buf = append(buf, line...)
+	}
+	buf = append(buf, "\r\n"...)
+	if c.auth != "" {
+		buf = append(buf, HeaderProxyAuthorization+": "...)
+		buf = append(buf, c.auth...)
+		buf = append(buf, "\r\n"...)
+	}
+	buf = append(buf, c.pending[i+2:]...)
+	c.pending = c.pending[:0]
+
+	if _, err := c.Conn.Write(buf); err != nil {
+		return 0, err //nolint:wrapcheck // This must not be wrapped
+	}
+	return len(p), nil
+}
+
+// Read implements net.Conn.
+func (c *httpProxyConn) Read(p []byte) (int, error) {
+	c.requestStart = true
+	return c.Conn.Read(p) //nolint:wrapcheck // This must not be wrapped
+}
+
+// socks5Connect establishes a connection to addr through a SOCKS5 proxy as
+// defined in RFC 1928, authenticating with RFC 1929 if user is set.
+func socks5Connect(conn net.Conn, addr string, user *url.Userinfo) error {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("fiber: invalid socks5 target: %w", err)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return fmt.Errorf("fiber: invalid socks5 target port: %w", err)
+	}
+
+	// Negotiate the authentication method
+	greeting := []byte{socks5Version, 1, socks5AuthNone}
+	if user != nil {
+		greeting = []byte{socks5Version, 2, socks5AuthNone, socks5AuthPasswd}
+	}
+	if _, err = conn.Write(greeting); err != nil {
+		return fmt.Errorf("fiber: failed to write socks5 greeting: %w", err)
+	}
+	var reply [4]byte
+	if _, err = io.ReadFull(conn, reply[:2]); err != nil {
+		return fmt.Errorf("fiber: failed to read socks5 greeting: %w", err)
+	}
+	if reply[0] != socks5Version {
+		return fmt.Errorf("fiber: unexpected socks version %d", reply[0])
+	}
+
+	switch reply[1] {
+	case socks5AuthNone:
+	case socks5AuthPasswd:
+		if user == nil {
+			return errSOCKS5Auth
+		}
+		pass, _ := user.Password()
+		username, password := []byte(user.Username()), []byte(pass)
+		if len(username) > 255 || len(password) > 255 {
+			return errors.New("fiber: socks5 username or password too long")
+		}
+		auth := make([]byte, 0, 3+len(username)+len(password))
+		auth = append(auth, 1, byte(len(username)))
+		auth = append(auth, username...)
+		auth = append(auth, byte(len(password)))
+		auth = append(auth, password...)
+		if _, err = conn.Write(auth); err != nil {
+			return fmt.Errorf("fiber: failed to write socks5 authentication: %w", err)
+		}
+		if _, err = io.ReadFull(conn, reply[:2]); err != nil {
+			return fmt.Errorf("fiber: failed to read socks5 authentication: %w", err)
+		}
+		if reply[1] != 0 {
+			return errors.New("fiber: socks5 authentication failed")
+		}
+	default:
+		return errSOCKS5Auth
+	}
+
+	// Request the connection
+	req := []byte{socks5Version, socks5CmdConnect, 0}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			req = append(req, socks5AtypIPv4)
+			req = append(req, ip4...)
+		} else {
+			req = append(req, socks5AtypIPv6)
+			req = append(req, ip.To16()...)
+		}
+	} else {
+		if len(host) > 255 {
+			return errors.New("fiber: socks5 target host too long")
+		}
+		req = append(req, socks5AtypDomain, byte(len(host)))
+		req = append(req, host...)
+	}
+	req = binary.BigEndian.AppendUint16(req, uint16(port))
+	if _, err = conn.Write(req); err != nil {
+		return fmt.Errorf("fiber: failed to write socks5 request: %w", err)
+	}
+
+	if _, err = io.ReadFull(conn, reply[:4]); err != nil {
+		return fmt.Errorf("fiber: failed to read socks5 reply: %w", err)
+	}
+	if reply[1] != 0 {
+		return fmt.Errorf("fiber: socks5 proxy refused connection to %s with code %d", addr, reply[1])
+	}
+
+	// Skip the bound address and port
+	var skip int
+	switch reply[3] {
+	case socks5AtypIPv4:
+		skip = net.IPv4len + 2
+	case socks5AtypIPv6:
+		skip = net.IPv6len + 2
+	case socks5AtypDomain:
+		if _, err = io.ReadFull(conn, reply[:1]); err != nil {
+			return fmt.Errorf("fiber: failed to read socks5 reply: %w", err)
+		}
+		skip = int(reply[0]) + 2
+	default:
+		return fmt.Errorf("fiber: unexpected socks5 address type %d", reply[3])
+	}
+	if _, err = io.CopyN(io.Discard, conn, int64(skip)); err != nil {
+		return fmt.Errorf("fiber: failed to read socks5 reply: %w", err)
+	}
+
+	return nil
+}
+
+// getEnvAny returns the value of the first set environment variable.
+func getEnvAny(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// useProxy reports whether addr must be proxied according to the NO_PROXY
+// value noProxy. Entries are hosts or domains with an optional port, IP
+// addresses or CIDR ranges, and "*" disables proxying entirely.
+func useProxy(addr, noProxy string) bool {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	host = utils.ToLower(host)
+	ip := net.ParseIP(host)
+
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = utils.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return false
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return false
+			}
+			continue
+		}
+
+		if h, p, err := net.SplitHostPort(entry); err == nil {
+			if p != port {
+				continue
+			}
+			entry = h
+		}
+		if entryIP := net.ParseIP(entry); entryIP != nil {
+			if ip != nil && entryIP.Equal(ip) {
+				return false
+			}
+			continue
+		}
+
+		entry = strings.TrimPrefix(entry, "*")
+		domain := strings.TrimPrefix(entry, ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,221 @@
+package fiber
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v3/addon/retry"
+	"github.com/gofiber/utils/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// RetryConfig defines the retry policy of a Client or Agent.
+//
+// Requests are only retried if their method is idempotent or if they carry
+// an idempotency key header, and never if the request body is a stream.
+type RetryConfig struct {
+	// Backoff configures the exponential backoff with jitter between
+	// attempts, starting with its InitialInterval. Its MaxRetryCount is
+	// not used, see MaxAttempts.
+	//
+	// Optional. Default: retry.DefaultConfig
+	Backoff retry.Config
+
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	//
+	// Optional. Default: 3
+	MaxAttempts int
+
+	// StatusCodes defines the response status codes which are retried.
+	// Network errors are always retried.
+	//
+	// Optional. Default: 429, 502, 503, 504
+	StatusCodes []int
+
+	// IdempotencyKeyHeader is the header which makes requests with a
+	// non-idempotent method safe to retry.
+	//
+	// Optional. Default: "X-Idempotency-Key"
+	IdempotencyKeyHeader string
+}
+
+// RetryConfigDefault is the default retry policy.
+var RetryConfigDefault = RetryConfig{
+	Backoff:     retry.DefaultConfig,
+	MaxAttempts: 3,
+	StatusCodes: []int{
+		StatusTooManyRequests,
+		StatusBadGateway,
+		StatusServiceUnavailable,
+		StatusGatewayTimeout,
+	},
+	IdempotencyKeyHeader: "X-Idempotency-Key",
+}
+
+// retryConfigDefault sets the retry policy values if they are not set.
+func retryConfigDefault(config ...RetryConfig) RetryConfig {
+	if len(config) < 1 {
+		return RetryConfigDefault
+	}
+
+	cfg := config[0]
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = RetryConfigDefault.MaxAttempts
+	}
+	if cfg.StatusCodes == nil {
+		cfg.StatusCodes = RetryConfigDefault.StatusCodes
+	}
+	if cfg.IdempotencyKeyHeader == "" {
+		cfg.IdempotencyKeyHeader = RetryConfigDefault.IdempotencyKeyHeader
+	}
+
+	return cfg
+}
+
+// Retry enables retries with exponential backoff for the request.
+//
+// It overrides the retry policy of the Client.
+func (a *Agent) Retry(config ...RetryConfig) *Agent {
+	cfg := retryConfigDefault(config...)
+	a.retry = &cfg
+
+	return a
+}
+
+// Attempts returns the number of attempts made by the last request.
+//
+// The Agent is released after Bytes, String and Struct unless Reuse is
+// enabled, so Attempts must be used together with Reuse.
+func (a *Agent) Attempts() int {
+	return a.attempts
+}
+
+// doRetry calls handler until the request succeeds, the retry policy
+// doesn't allow another attempt or the attempts are exhausted.
+func (a *Agent) doRetry(handler ClientHandler, req *Request, resp *Response) error {
+	cfg := a.retry
+	backoff := cfg.newBackoff()
+
+	retryable := !req.IsBodyStream() &&
+		(IsMethodIdempotent(utils.UnsafeString(req.Header.Method())) ||
+			len(req.Header.Peek(cfg.IdempotencyKeyHeader)) > 0)
+
+	for a.attempts = 1; ; a.attempts++ {
+		err := handler(req, resp)
+		if !retryable || a.attempts >= cfg.MaxAttempts || a.contextDone() || !cfg.shouldRetry(resp, err) {
+			return err
+		}
+
+		wait := backoff.Next()
+		if err == nil {
+			if d, ok := parseRetryAfter(resp.Header.Peek(HeaderRetryAfter)); ok {
+				wait = d
+				if wait > backoff.MaxBackoffTime {
+					wait = backoff.MaxBackoffTime
+				}
+			}
+		}
+
+		resp.Reset()
+		if err := a.sleep(wait); err != nil {
+			return err
+		}
+	}
+}
+
+// newBackoff returns the backoff between the attempts, which starts with the
+// InitialInterval of the config.
+func (cfg *RetryConfig) newBackoff() *retry.ExponentialBackoff {
+	// apply the defaults of the addon
+	backoff := retry.NewExponentialBackoff(cfg.Backoff)
+	// a backoff without current interval starts with InitialInterval
+	return &retry.ExponentialBackoff{
+		InitialInterval: backoff.InitialInterval,
+		MaxBackoffTime:  backoff.MaxBackoffTime,
+		Multiplier:      backoff.Multiplier,
+		MaxJitter:       backoff.MaxJitter,
+	}
+}
+
+// contextDone reports whether the context bound to the Agent is done.
+func (a *Agent) contextDone() bool {
+	return a.ctx != nil && a.ctx.Err() != nil
+}
+
+// sleep waits for d, returning early with the context error if the context
+// bound to the Agent is done.
+func (a *Agent) sleep(d time.Duration) error {
+	if a.ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-a.ctx.Done():
+		return a.ctx.Err()
+	}
+}
+
+// shouldRetry reports whether the outcome of an attempt is retryable.
+func (cfg *RetryConfig) shouldRetry(resp *Response, err error) bool {
+	if err != nil {
+		return isNetworkError(err)
+	}
+
+	status := resp.StatusCode()
+	for _, code := range cfg.StatusCodes {
+		if code == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNetworkError reports whether err was caused by the connection to the
+// host rather than by the request itself.
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, fasthttp.ErrConnectionClosed) ||
+		errors.Is(err, fasthttp.ErrNoFreeConns) ||
+		errors.Is(err, fasthttp.ErrDialTimeout)
+}
+
+// parseRetryAfter parses the Retry-After header value, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(v []byte) (time.Duration, bool) {
+	if len(v) == 0 {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(utils.UnsafeString(v)); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := fasthttp.ParseHTTPDate(v)
+	if err != nil {
+		return 0, false
+	}
+	d := time.Until(date)
+	if d < 0 {
+		d = 0
+	}
+
+	return d, true
+}
@@ -0,0 +1,175 @@
+package fiber
+
+import (
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3/addon/retry"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func testRetryConfig() RetryConfig {
+	return RetryConfig{
+		Backoff: retry.Config{
+			InitialInterval: time.Millisecond,
+			MaxBackoffTime:  10 * time.Millisecond,
+			MaxJitter:       time.Millisecond,
+		},
+		MaxAttempts: 3,
+	}
+}
+
+func testRetryServer(t *testing.T, handler Handler) *fasthttputil.InmemoryListener {
+	t.Helper()
+
+	app := New()
+	app.All("/", handler)
+
+	return testClientServer(t, app, nil)
+}
+
+// go test -run Test_Client_Agent_Retry
+func Test_Client_Agent_Retry(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	ln := testRetryServer(t, func(c Ctx) error {
+		if atomic.AddInt32(&calls, 1)%3 != 0 {
+			return c.SendStatus(StatusServiceUnavailable)
+		}
+		return c.SendString("ok")
+	})
+
+	a := Get("http://example.com").Retry(testRetryConfig()).Reuse()
+	a.HostClient.Dial = func(addr string) (net.Conn, error) { return ln.Dial() }
+	defer ReleaseAgent(a)
+
+	code, body, errs := a.String()
+
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, StatusOK, code)
+	require.Equal(t, "ok", body)
+	require.Equal(t, 3, a.Attempts())
+}
+
+// go test -run Test_Client_Agent_Retry_Exhausted
+func Test_Client_Agent_Retry_Exhausted(t *testing.T) {
+	t.Parallel()
+
+	ln := testRetryServer(t, func(c Ctx) error {
+		c.Set(HeaderRetryAfter, "0")
+		return c.SendStatus(StatusTooManyRequests)
+	})
+
+	a := Get("http://example.com").Retry(testRetryConfig()).Reuse()
+	a.HostClient.Dial = func(addr string) (net.Conn, error) { return ln.Dial() }
+	defer ReleaseAgent(a)
+
+	code, _, errs := a.String()
+
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, StatusTooManyRequests, code)
+	require.Equal(t, 3, a.Attempts())
+}
+
+// go test -run Test_Client_Agent_Retry_NonIdempotent
+func Test_Client_Agent_Retry_NonIdempotent(t *testing.T) {
+	t.Parallel()
+
+	ln := testRetryServer(t, func(c Ctx) error {
+		return c.SendStatus(StatusBadGateway)
+	})
+
+	t.Run("without idempotency key", func(t *testing.T) {
+		t.Parallel()
+
+		a := Post("http://example.com").Retry(testRetryConfig()).Reuse()
+		a.HostClient.Dial = func(addr string) (net.Conn, error) { return ln.Dial() }
+		defer ReleaseAgent(a)
+
+		code, _, errs := a.String()
+
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, StatusBadGateway, code)
+		require.Equal(t, 1, a.Attempts())
+	})
+
+	t.Run("with idempotency key", func(t *testing.T) {
+		t.Parallel()
+
+		a := Post("http://example.com").
+			Set("X-Idempotency-Key", "00000000-0000-0000-0000-000000000000").
+			Retry(testRetryConfig()).
+			Reuse()
+		a.HostClient.Dial = func(addr string) (net.Conn, error) { return ln.Dial() }
+		defer ReleaseAgent(a)
+
+		code, _, errs := a.String()
+
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, StatusBadGateway, code)
+		require.Equal(t, 3, a.Attempts())
+	})
+}
+
+// go test -run Test_Client_Retry_NetworkError
+func Test_Client_Retry_NetworkError(t *testing.T) {
+	t.Parallel()
+
+	ln := testRetryServer(t, func(c Ctx) error {
+		return c.SendString("ok")
+	})
+
+	var dials int32
+	cfg := testRetryConfig()
+	c := &Client{
+		Retry: &cfg,
+		Dial: func(addr string) (net.Conn, error) {
+			if atomic.AddInt32(&dials, 1) == 1 {
+				return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+			}
+			return ln.Dial()
+		},
+	}
+
+	a := c.Get("http://example.com").Reuse()
+	defer ReleaseAgent(a)
+
+	code, body, errs := a.String()
+
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, StatusOK, code)
+	require.Equal(t, "ok", body)
+	require.Equal(t, 2, a.Attempts())
+}
+
+// go test -run Test_Client_parseRetryAfter
+func Test_Client_parseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	d, ok := parseRetryAfter([]byte("120"))
+	require.True(t, ok)
+	require.Equal(t, 2*time.Minute, d)
+
+	d, ok = parseRetryAfter(fasthttp.AppendHTTPDate(nil, time.Now().Add(time.Hour)))
+	require.True(t, ok)
+	require.InDelta(t, time.Hour, d, float64(2*time.Second))
+
+	d, ok = parseRetryAfter(fasthttp.AppendHTTPDate(nil, time.Now().Add(-time.Hour)))
+	require.True(t, ok)
+	require.Equal(t, time.Duration(0), d)
+
+	_, ok = parseRetryAfter([]byte("-1"))
+	require.False(t, ok)
+
+	_, ok = parseRetryAfter([]byte("soon"))
+	require.False(t, ok)
+
+	_, ok = parseRetryAfter(nil)
+	require.False(t, ok)
+}
@@ -0,0 +1,152 @@
+package fiber
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/valyala/fasthttp"
+)
+
+// StreamResponse is a response whose body is read from the connection on
+// demand instead of being buffered in memory.
+//
+// The body is read with Read, and Close must be called once the response is
+// no longer used to release the connection.
+type StreamResponse struct {
+	resp   *Response
+	body   io.Reader
+	closed bool
+}
+
+// Stream sends the request and returns the response as soon as the status
+// line and the headers are received.
+//
+// Dest and SetResponse are ignored for streamed responses. Chunked bodies and
+// bodies larger than the MaxResponseBodySize of the HostClient are read from
+// the connection while the caller consumes them.
+//
+// it's not safe to use Agent after calling [Agent.Stream]
+func (a *Agent) Stream() (*StreamResponse, []error) {
+	defer a.release()
+
+	if len(a.errs) > 0 {
+		return nil, append([]error(nil), a.errs...)
+	}
+
+	req, resp := a.req, AcquireResponse()
+	resp.StreamBody = true
+
+	if err := a.send(req, resp); err != nil {
+		if a.debugWriter != nil {
+			printStreamDebugInfo(req, resp, a.debugWriter)
+		}
+		ReleaseResponse(resp)
+		return nil, []error{err}
+	}
+	if a.debugWriter != nil {
+		printStreamDebugInfo(req, resp, a.debugWriter)
+	}
+
+	body := resp.BodyStream()
+	if body == nil {
+		body = bytes.NewReader(resp.Body())
+	}
+
+	return &StreamResponse{resp: resp, body: body}, nil
+}
+
+// printStreamDebugInfo is like printDebugInfo, but doesn't consume the body.
+func printStreamDebugInfo(req *Request, resp *Response, w io.Writer) {
+	_, _ = fmt.Fprintf(w, "Connected to %s(%s)\r\n\r\n", req.URI().Host(), resp.RemoteAddr()) //nolint:errcheck // This will never fail
+	_, _ = req.WriteTo(w)                                                                     //nolint:errcheck // This will never fail
+	_, _ = resp.Header.WriteTo(w)                                                             //nolint:errcheck // This will never fail
+}
+
+// StatusCode returns the response status code.
+func (r *StreamResponse) StatusCode() int {
+	return r.resp.StatusCode()
+}
+
+// Header returns the response headers.
+func (r *StreamResponse) Header() *fasthttp.ResponseHeader {
+	return &r.resp.Header
+}
+
+// ContentLength returns the length of the body, or -1 if it is unknown.
+func (r *StreamResponse) ContentLength() int {
+	return r.resp.Header.ContentLength()
+}
+
+// Read reads the response body. It implements io.Reader.
+func (r *StreamResponse) Read(p []byte) (int, error) {
+	if r.closed {
+		return 0, errStreamClosed
+	}
+	return r.body.Read(p) //nolint:wrapcheck // This must not be wrapped
+}
+
+// Close releases the response and its connection. It implements io.Closer.
+func (r *StreamResponse) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	err := r.resp.CloseBodyStream()
+	ReleaseResponse(r.resp)
+	r.resp = nil
+
+	return err //nolint:wrapcheck // This must not be wrapped
+}
+
+// SaveFile writes the response body to the named file and closes the response.
+func (r *StreamResponse) SaveFile(path string) error {
+	defer r.Close() //nolint:errcheck // The copy error is more relevant
+
+	f, err := os.Create(path) //nolint:gosec // The path is provided by the caller
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close() //nolint:errcheck // The copy error is more relevant
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+
+	return nil
+}
+
+// Pipe sends the response with its status code and headers as the response
+// of c. The body is copied while c writes its response, so a slow receiver
+// slows down reading from the upstream connection. The response is closed
+// once it has been sent.
+func (r *StreamResponse) Pipe(c Ctx) error {
+	c.Status(r.StatusCode())
+	r.resp.Header.VisitAll(func(key, value []byte) {
+		switch string(key) {
+		case HeaderContentLength, HeaderTransferEncoding, HeaderConnection:
+			return
+		}
+		c.Response().Header.AddBytesKV(key, value)
+	})
+
+	return c.SendStream(r, r.ContentLength())
+}
+
+var errStreamClosed = errors.New("fiber: read on closed response stream")
+
+// streamReader attaches a close function to a body stream.
+type streamReader struct {
+	io.Reader
+	close func() error
+}
+
+// Close implements io.Closer.
+func (s *streamReader) Close() error {
+	return s.close()
+}
@@ -0,0 +1,166 @@
+package fiber
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func testStreamServer(t *testing.T) (*fasthttputil.InmemoryListener, chan struct{}) {
+	t.Helper()
+
+	next := make(chan struct{})
+
+	app := New()
+
+	app.Get("/ndjson", func(c Ctx) error {
+		c.Set(HeaderContentType, "application/x-ndjson")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			_, _ = w.WriteString(`{"n":1}` + "\n") //nolint:errcheck // We're in a test
+			_ = w.Flush()                          //nolint:errcheck // We're in a test
+			<-next
+			_, _ = w.WriteString(`{"n":2}` + "\n") //nolint:errcheck // We're in a test
+		})
+		return nil
+	})
+	app.Get("/large", func(c Ctx) error {
+		return c.SendString(strings.Repeat("a", 64*1024))
+	})
+
+	return testClientServer(t, app, nil), next
+}
+
+// go test -run Test_Client_Agent_Stream
+func Test_Client_Agent_Stream(t *testing.T) {
+	t.Parallel()
+
+	ln, next := testStreamServer(t)
+	c := &Client{
+		MaxResponseBodySize: 1024,
+		Dial:                func(addr string) (net.Conn, error) { return ln.Dial() },
+	}
+
+	t.Run("chunked", func(t *testing.T) {
+		t.Parallel()
+
+		resp, errs := c.Get("http://example.com/ndjson").Stream()
+		require.Equal(t, 0, len(errs))
+		defer func() { require.NoError(t, resp.Close()) }()
+
+		require.Equal(t, StatusOK, resp.StatusCode())
+		require.Equal(t, "application/x-ndjson", string(resp.Header().ContentType()))
+		require.Equal(t, -1, resp.ContentLength())
+
+		// The first line is readable before the server has finished
+		r := bufio.NewReader(resp)
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		require.Equal(t, `{"n":1}`+"\n", line)
+
+		close(next)
+
+		rest, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, `{"n":2}`+"\n", string(rest))
+	})
+
+	t.Run("larger than max body size", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, errs := c.Get("http://example.com/large").Bytes()
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], fasthttp.ErrBodyTooLarge)
+
+		resp, errs := c.Get("http://example.com/large").Stream()
+		require.Equal(t, 0, len(errs))
+
+		body, err := io.ReadAll(resp)
+		require.NoError(t, err)
+		require.Equal(t, 64*1024, len(body))
+		require.NoError(t, resp.Close())
+
+		_, err = resp.Read(make([]byte, 1))
+		require.ErrorIs(t, err, errStreamClosed)
+	})
+
+	t.Run("with context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		resp, errs := c.Get("http://example.com/large").WithContext(ctx).Stream()
+		require.Equal(t, 0, len(errs))
+
+		body, err := io.ReadAll(resp)
+		require.NoError(t, err)
+		require.Equal(t, 64*1024, len(body))
+		require.NoError(t, resp.Close())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		resp, errs := c.Get("ftp://example.com").Stream()
+		require.Nil(t, resp)
+		require.Equal(t, 1, len(errs))
+	})
+}
+
+// go test -run Test_Client_StreamResponse_SaveFile
+func Test_Client_StreamResponse_SaveFile(t *testing.T) {
+	t.Parallel()
+
+	ln, _ := testStreamServer(t)
+	c := &Client{
+		Dial: func(addr string) (net.Conn, error) { return ln.Dial() },
+	}
+
+	resp, errs := c.Get("http://example.com/large").Stream()
+	require.Equal(t, 0, len(errs))
+
+	path := filepath.Join(t.TempDir(), "large.txt")
+	require.NoError(t, resp.SaveFile(path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("a", 64*1024), string(content))
+}
+
+// go test -run Test_Client_StreamResponse_Pipe
+func Test_Client_StreamResponse_Pipe(t *testing.T) {
+	t.Parallel()
+
+	ln, _ := testStreamServer(t)
+	c := &Client{
+		Dial: func(addr string) (net.Conn, error) { return ln.Dial() },
+	}
+
+	app := New()
+	app.Get("/", func(ctx Ctx) error {
+		resp, errs := c.Get("http://example.com/large").Stream()
+		if len(errs) > 0 {
+			return errs[0]
+		}
+		return resp.Pipe(ctx)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(MethodGet, "/", nil))
+	require.NoError(t, err)
+	require.Equal(t, StatusOK, resp.StatusCode)
+	require.Equal(t, MIMETextPlainCharsetUTF8, resp.Header.Get(HeaderContentType))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("a", 64*1024), string(body))
+}
@@ -3,6 +3,7 @@ package fiber
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
@@ -15,11 +16,14 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gofiber/fiber/v3/internal/tlstest"
 	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
 	"github.com/valyala/fasthttp/fasthttputil"
 )
 
@@ -1271,6 +1275,454 @@ func Test_Client_Agent_Parse(t *testing.T) {
 	require.Nil(t, a.Parse())
 }
 
+func Test_Client_ConnectionPool(t *testing.T) {
+	t.Parallel()
+
+	ln := fasthttputil.NewInmemoryListener()
+
+	app := New()
+
+	app.Get("/", func(c Ctx) error {
+		return c.SendString("pool")
+	})
+
+	go func() {
+		require.Nil(t, app.Listener(ln, ListenConfig{
+			DisableStartupMessage: true,
+		}))
+	}()
+
+	var dials int32
+	c := &Client{
+		Dial: func(addr string) (net.Conn, error) {
+			atomic.AddInt32(&dials, 1)
+			return ln.Dial()
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		code, body, errs := c.Get("http://example.com").String()
+
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, StatusOK, code)
+		require.Equal(t, "pool", body)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&dials))
+
+	// Agent settings must not leak into the shared pool
+	a := c.Get("http://example.com").TLSConfig(&tls.Config{ServerName: "agent"}) //nolint:gosec // We're in a test
+	require.NotSame(t, c.hostClient("example.com:80", false), a.HostClient)
+	require.Nil(t, c.hostClient("example.com:80", false).TLSConfig)
+	ReleaseAgent(a)
+
+	// Direct writes to the HostClient of an agent detach it from the shared pool
+	a = c.Get("http://example.com")
+	a.ReadTimeout = time.Second
+	code, body, errs := a.String()
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, StatusOK, code)
+	require.Equal(t, "pool", body)
+	require.Equal(t, int32(2), atomic.LoadInt32(&dials))
+	require.Equal(t, time.Duration(0), c.hostClient("example.com:80", false).ReadTimeout)
+
+	require.Same(t, c.hostClient("example.com:443", true), c.hostClient("example.com:443", true))
+	require.NotSame(t, c.hostClient("example.com:80", false), c.hostClient("example.com:443", true))
+
+	// The pool is rebuilt when the Client is reconfigured
+	hc := c.hostClient("example.com:80", false)
+	c.MaxConnsPerHost = 16
+	require.NotSame(t, hc, c.hostClient("example.com:80", false))
+	require.Equal(t, 16, c.hostClient("example.com:80", false).MaxConns)
+	code, _, errs = c.Get("http://example.com").String()
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, StatusOK, code)
+	require.Equal(t, int32(3), atomic.LoadInt32(&dials))
+
+	c.CloseIdleConnections()
+}
+
+// go test -run Test_Client_App
+func Test_Client_App(t *testing.T) {
+	t.Parallel()
+
+	users := New()
+	users.Get("/users/:id", func(c Ctx) error {
+		return c.JSON(Map{"id": c.Params("id"), "host": c.Hostname(), "scheme": c.Scheme()})
+	})
+	users.Get("/me", func(c Ctx) error {
+		return c.Redirect().To("/users/1")
+	})
+
+	usersClient := &Client{App: users, BaseURL: "http://users.internal"}
+
+	gateway := New()
+	gateway.Get("/profile", func(c Ctx) error {
+		resp, errs := usersClient.Get("/me").MaxRedirectsCount(1).Stream()
+		if len(errs) > 0 {
+			return errs[0]
+		}
+		return resp.Pipe(c)
+	})
+
+	c := &Client{App: gateway}
+
+	t.Run("service to service", func(t *testing.T) {
+		t.Parallel()
+
+		var user struct {
+			ID     string `json:"id"`
+			Host   string `json:"host"`
+			Scheme string `json:"scheme"`
+		}
+		code, _, errs := c.Get("http://gateway.internal/profile").Struct(&user)
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, StatusOK, code)
+		require.Equal(t, "1", user.ID)
+		require.Equal(t, "users.internal", user.Host)
+		require.Equal(t, schemeHTTP, user.Scheme)
+	})
+
+	t.Run("https is not supported", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, errs := usersClient.Get("https://users.internal/users/2").String()
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], errInmemoryTLS)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		code, _, errs := c.Get("http://gateway.internal/unknown").String()
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, StatusNotFound, code)
+	})
+}
+
+// go test -run Test_Client_App_StartupOnce
+func Test_Client_App_StartupOnce(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	app.Get("/", func(c Ctx) error {
+		return c.SendString("index")
+	})
+	c := &Client{App: app}
+
+	code, body, errs := c.Get("http://app.internal/").ConnectionClose().String()
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, StatusOK, code)
+	require.Equal(t, "index", body)
+
+	// The app is prepared on the first dial only, later routes are served right away
+	app.Get("/later", func(c Ctx) error {
+		return c.SendString("later")
+	})
+	code, body, errs = c.Get("http://app.internal/later").String()
+	require.Equal(t, 0, len(errs))
+	require.Equal(t, StatusOK, code)
+	require.Equal(t, "later", body)
+}
+
+func Test_Client_Defaults(t *testing.T) {
+	t.Parallel()
+
+	ln := fasthttputil.NewInmemoryListener()
+
+	app := New()
+
+	app.Get("/api/users", func(c Ctx) error {
+		return c.SendString(c.Get("X-Api-Key") + ":" + c.Cookies("session"))
+	})
+	app.Get("/slow", func(c Ctx) error {
+		time.Sleep(100 * time.Millisecond)
+		return c.SendString("slow")
+	})
+
+	go func() {
+		require.Nil(t, app.Listener(ln, ListenConfig{
+			DisableStartupMessage: true,
+		}))
+	}()
+
+	c := &Client{
+		BaseURL: "http://example.com/api/",
+		Headers: map[string]string{"X-Api-Key": "secret"},
+		Cookies: map[string]string{"session": "abc"},
+		Timeout: 50 * time.Millisecond,
+		Dial:    func(addr string) (net.Conn, error) { return ln.Dial() },
+	}
+
+	t.Run("base url and headers", func(t *testing.T) {
+		t.Parallel()
+
+		code, body, errs := c.Get("/users").String()
+
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, StatusOK, code)
+		require.Equal(t, "secret:abc", body)
+	})
+
+	t.Run("absolute url", func(t *testing.T) {
+		t.Parallel()
+
+		a := c.Get("http://example.com/slow")
+		require.Equal(t, "http://example.com/slow", a.Request().URI().String())
+		ReleaseAgent(a)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, errs := c.Get("http://example.com/slow").String()
+
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], fasthttp.ErrTimeout)
+	})
+
+	t.Run("agent timeout overrides client", func(t *testing.T) {
+		t.Parallel()
+
+		code, body, errs := c.Get("http://example.com/slow").Timeout(time.Second).String()
+
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, StatusOK, code)
+		require.Equal(t, "slow", body)
+	})
+}
+
+func Test_Client_Interceptors(t *testing.T) {
+	t.Parallel()
+
+	ln := fasthttputil.NewInmemoryListener()
+
+	app := New()
+
+	app.Get("/", func(c Ctx) error {
+		return c.SendString(c.Get(HeaderAuthorization))
+	})
+
+	go func() {
+		require.Nil(t, app.Listener(ln, ListenConfig{
+			DisableStartupMessage: true,
+		}))
+	}()
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	record := func(name string) ClientInterceptor {
+		return func(req *Request, resp *Response, next ClientHandler) error {
+			mu.Lock()
+			order = append(order, name+":before")
+			mu.Unlock()
+			err := next(req, resp)
+			mu.Lock()
+			order = append(order, name+":after")
+			mu.Unlock()
+			return err
+		}
+	}
+
+	c := &Client{
+		Dial: func(addr string) (net.Conn, error) { return ln.Dial() },
+		Interceptors: []ClientInterceptor{
+			record("client"),
+			BeforeRequest(func(req *Request) error {
+				req.Header.Set(HeaderAuthorization, "Bearer token")
+				return nil
+			}),
+		},
+	}
+
+	t.Run("order and mutation", func(t *testing.T) {
+		code, body, errs := c.Get("http://example.com").
+			Use(record("agent"), AfterResponse(func(req *Request, resp *Response, err error) error {
+				resp.SetBodyString(string(resp.Body()) + "!")
+				return err
+			})).
+			String()
+
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, StatusOK, code)
+		require.Equal(t, "Bearer token!", body)
+		require.Equal(t, []string{"client:before", "agent:before", "agent:after", "client:after"}, order)
+	})
+
+	t.Run("short-circuit", func(t *testing.T) {
+		code, body, errs := c.Get("http://example.com").
+			Use(func(req *Request, resp *Response, next ClientHandler) error {
+				resp.SetStatusCode(StatusTeapot)
+				resp.SetBodyString("cached")
+				return nil
+			}).
+			String()
+
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, StatusTeapot, code)
+		require.Equal(t, "cached", body)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errDenied := errors.New("denied")
+		_, _, errs := c.Get("http://example.com").
+			Use(AfterResponse(func(req *Request, resp *Response, err error) error {
+				return fmt.Errorf("wrapped: %w", err)
+			})).
+			Use(BeforeRequest(func(req *Request) error {
+				return errDenied
+			})).
+			String()
+
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], errDenied)
+		require.Equal(t, "wrapped: denied", errs[0].Error())
+	})
+}
+
+func Test_Client_Agent_WithContext(t *testing.T) {
+	t.Parallel()
+
+	ln := fasthttputil.NewInmemoryListener()
+
+	app := New()
+
+	app.Get("/", func(c Ctx) error {
+		return c.SendString("context")
+	})
+	app.Get("/slow", func(c Ctx) error {
+		time.Sleep(time.Second)
+		return c.SendString("slow")
+	})
+
+	go func() {
+		require.Nil(t, app.Listener(ln, ListenConfig{
+			DisableStartupMessage: true,
+		}))
+	}()
+
+	c := &Client{
+		Dial: func(addr string) (net.Conn, error) { return ln.Dial() },
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		code, body, errs := c.Get("http://example.com").WithContext(ctx).String()
+
+		require.Equal(t, 0, len(errs))
+		require.Equal(t, StatusOK, code)
+		require.Equal(t, "context", body)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		start := time.Now()
+		_, _, errs := c.Get("http://example.com/slow").WithContext(ctx).String()
+
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], context.Canceled)
+		require.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("canceled closes connection", func(t *testing.T) {
+		t.Parallel()
+
+		var conn net.Conn
+		c := &Client{
+			Dial: func(addr string) (net.Conn, error) {
+				var err error
+				conn, err = ln.Dial()
+				return conn, err
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		_, _, errs := c.Get("http://example.com/slow").WithContext(ctx).String()
+
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], context.Canceled)
+		// The connection is closed before the request returns
+		_, err := conn.Write([]byte("GET / HTTP/1.1\r\n\r\n"))
+		require.Error(t, err)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, _, errs := c.Get("http://example.com/slow").Timeout(5 * time.Second).WithContext(ctx).String()
+
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], context.DeadlineExceeded)
+		require.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("done before request", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _, errs := c.Get("http://example.com").WithContext(ctx).String()
+
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], context.Canceled)
+	})
+
+	t.Run("stops retries", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		a := c.Get("http://example.com/slow").
+			Retry(RetryConfig{StatusCodes: []int{StatusOK}}).
+			WithContext(ctx).
+			Reuse()
+		defer ReleaseAgent(a)
+
+		_, _, errs := a.String()
+
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], context.DeadlineExceeded)
+		require.Equal(t, 1, a.Attempts())
+	})
+}
+
+// testClientServer serves the app on an in-memory listener, with TLS if a config
+// is given, and returns the listener for the client to dial
+func testClientServer(t *testing.T, app *App, tlsConfig *tls.Config) *fasthttputil.InmemoryListener {
+	t.Helper()
+
+	ln := fasthttputil.NewInmemoryListener()
+	var serverLn net.Listener = ln
+	if tlsConfig != nil {
+		serverLn = tls.NewListener(ln, tlsConfig)
+	}
+
+	go func() {
+		require.Nil(t, app.Listener(serverLn, ListenConfig{
+			DisableStartupMessage: true,
+		}))
+	}()
+
+	return ln
+}
+
 func testAgent(t *testing.T, handler Handler, wrapAgent func(agent *Agent), excepted string, count ...int) {
 	t.Helper()
 
@@ -0,0 +1,161 @@
+// ⚡️ Fiber is an Express inspired web framework written in Go with ☕️
+// 🤖 Github Repository: https://github.com/gofiber/fiber
+// 📌 API Documentation: https://docs.gofiber.io
+
+package fiber
+
+// RouteCondition is an additional condition, besides method, path and hostname,
+// which requests have to satisfy to match the routes registered with When
+type RouteCondition struct {
+	match   func(c Ctx) bool
+	accepts bool // Negotiates the media type, requests only failing such conditions get 406 Not Acceptable
+}
+
+// MatchHeader returns a condition matching requests with the given header value.
+// An empty value matches requests having the header with any value.
+func MatchHeader(key, value string) RouteCondition {
+	return RouteCondition{
+		match: func(c Ctx) bool {
+			if value == "" {
+				return len(c.Request().Header.Peek(key)) > 0
+			}
+			return c.Get(key) == value
+		},
+	}
+}
+
+// MatchAccepts returns a condition matching requests which accept one of the
+// given media types, see Accepts. If no route of a path accepts the media types
+// of the request, it is rejected with 406 Not Acceptable instead of 404 Not Found.
+func MatchAccepts(offers ...string) RouteCondition {
+	return RouteCondition{
+		match: func(c Ctx) bool {
+			return c.Accepts(offers...) != ""
+		},
+		accepts: true,
+	}
+}
+
+// MatchQuery returns a condition matching requests with the given query
+// parameter, optionally with the given value.
+func MatchQuery(key string, value ...string) RouteCondition {
+	return RouteCondition{
+		match: func(c Ctx) bool {
+			if len(value) == 0 {
+				return c.Context().QueryArgs().Has(key)
+			}
+			return c.Query(key) == value[0]
+		},
+	}
+}
+
+// MatchFunc returns a condition matching requests for which the function returns true.
+func MatchFunc(match func(c Ctx) bool) RouteCondition {
+	return RouteCondition{match: match}
+}
+
+// When is used to define routes which only match requests satisfying all of
+// the given conditions, like a Group without prefix. Routes with the same method
+// and path can be registered with different conditions, requests not satisfying
+// the conditions of a route continue with the next one.
+//
+//	app.When(fiber.MatchHeader("X-API-Version", "2")).Get("/users", usersV2)
+//	app.Get("/users", usersV1)
+func (app *App) When(conditions ...RouteCondition) Router {
+	grp := &Group{app: app, conditions: conditions}
+	if err := app.hooks.executeOnGroupHooks(*grp); err != nil {
+		panic(err)
+	}
+
+	return grp
+}
+
+// When is used to define routes which only match requests satisfying all of
+// the given conditions and the ones of the group, with the prefix of the group.
+//
+//	api := app.Group("/api")
+//	api.When(fiber.MatchQuery("preview")).Get("/users", usersPreview)
+func (grp *Group) When(conditions ...RouteCondition) Router {
+	newGrp := &Group{
+		Prefix:      grp.Prefix,
+		app:         grp.app,
+		parentGroup: grp,
+		domain:      grp.domain,
+		conditions:  joinConditions(grp.conditions, conditions),
+	}
+	if err := grp.app.hooks.executeOnGroupHooks(*newGrp); err != nil {
+		panic(err)
+	}
+
+	return newGrp
+}
+
+// joinConditions returns the conditions of both slices, a slice is only
+// copied if both are not empty
+func joinConditions(first, second []RouteCondition) []RouteCondition {
+	if len(first) == 0 || len(second) == 0 {
+		if len(first) == 0 {
+			return second
+		}
+		return first
+	}
+	conditions := make([]RouteCondition, 0, len(first)+len(second))
+	return append(append(conditions, first...), second...)
+}
+
+// matchConditions checks if the request satisfies all conditions of the route,
+// the route is set for the conditions to access its parameters
+func (r *Route) matchConditions(c CustomCtx) bool {
+	if len(r.conditions) == 0 {
+		return true
+	}
+	route := c.getRoute()
+	c.setRoute(r)
+	for _, condition := range r.conditions {
+		if !condition.match(c) {
+			c.setRoute(route)
+			return false
+		}
+	}
+	return true
+}
+
+// sameConditions checks if the routes were registered with the same conditions
+func (r *Route) sameConditions(route *Route) bool {
+	if len(r.conditions) != len(route.conditions) {
+		return false
+	}
+	return len(r.conditions) == 0 || &r.conditions[0] == &route.conditions[0]
+}
+
+// notAcceptable checks if the request only fails media type conditions of the route
+func (r *Route) notAcceptable(c CustomCtx) bool {
+	route := c.getRoute()
+	c.setRoute(r)
+	defer c.setRoute(route)
+
+	var failed bool
+	for _, condition := range r.conditions {
+		if !condition.match(c) {
+			if !condition.accepts {
+				return false
+			}
+			failed = true
+		}
+	}
+	return failed
+}
+
+// Scan stack if routes of the method only fail because of the accepted media types
+func (*App) conditionsNotAcceptable(c CustomCtx) bool {
+	for _, route := range c.getTreeStack()[c.getMethodINT()].find(c.getDetectionPath()) {
+		if route.use || route.mount || len(route.conditions) == 0 {
+			continue
+		}
+		if route.match(c.getDetectionPath(), c.Path(), c.getValues()) && route.matchDomain(c, c.getValues()) &&
+			route.notAcceptable(c) {
+			return true
+		}
+	}
+	return false
+}
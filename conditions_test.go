@@ -0,0 +1,185 @@
+// ⚡️ Fiber is an Express inspired web framework written in Go with ☕️
+// 🤖 Github Repository: https://github.com/gofiber/fiber
+// 📌 API Documentation: https://docs.gofiber.io
+
+//nolint:bodyclose // Much easier to just ignore memory leaks in tests
+package fiber
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testConditionRequest(t *testing.T, app *App, method, target string, headers map[string]string) (int, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, http.NoBody)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err, "app.Test(req)")
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
+
+// go test -run Test_App_When
+func Test_App_When(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	app.When(MatchHeader("X-API-Version", "2")).Get("/users", func(c Ctx) error {
+		return c.SendString("users v2 header")
+	})
+	app.When(MatchQuery("preview")).Get("/users", func(c Ctx) error {
+		return c.SendString("users preview")
+	})
+	app.When(MatchQuery("format", "csv"), MatchHeader("X-Export", "")).Get("/users", func(c Ctx) error {
+		return c.SendString("users csv")
+	})
+	app.When(MatchFunc(func(c Ctx) bool {
+		return c.Params("id") == "me"
+	})).Get("/users/:id", func(c Ctx) error {
+		return c.SendString("current user")
+	})
+	app.Get("/users", func(c Ctx) error {
+		return c.SendString("users v1")
+	})
+	app.Get("/users/:id", func(c Ctx) error {
+		return c.SendString("user " + c.Params("id"))
+	})
+
+	testCases := []struct {
+		target  string
+		headers map[string]string
+		code    int
+		body    string
+	}{
+		{target: "/users", code: StatusOK, body: "users v1"},
+		{target: "/users", headers: map[string]string{"X-API-Version": "2"}, code: StatusOK, body: "users v2 header"},
+		{target: "/users", headers: map[string]string{"X-API-Version": "3"}, code: StatusOK, body: "users v1"},
+		{target: "/users?preview", code: StatusOK, body: "users preview"},
+		{target: "/users?format=csv", code: StatusOK, body: "users v1"},
+		{target: "/users?format=csv", headers: map[string]string{"X-Export": "1"}, code: StatusOK, body: "users csv"},
+		{target: "/users/me", code: StatusOK, body: "current user"},
+		{target: "/users/42", code: StatusOK, body: "user 42"},
+	}
+
+	for _, tc := range testCases {
+		code, body := testConditionRequest(t, app, MethodGet, tc.target, tc.headers)
+		require.Equal(t, tc.code, code, tc.target)
+		require.Equal(t, tc.body, body, tc.target)
+	}
+
+	// routes with conditions are kept apart from the ones without
+	require.Len(t, app.stack[app.methodInt(MethodGet)], 6)
+}
+
+// go test -run Test_App_When_NotAcceptable
+func Test_App_When_NotAcceptable(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	app.When(MatchAccepts("application/vnd.acme.v2+json")).Get("/users", func(c Ctx) error {
+		return c.SendString("users v2")
+	})
+	app.When(MatchAccepts("application/vnd.acme.v1+json", "application/json")).Get("/users", func(c Ctx) error {
+		return c.SendString("users v1")
+	})
+	app.When(MatchHeader("X-Admin", "true"), MatchAccepts("application/json")).Get("/admin", func(c Ctx) error {
+		return c.SendString("admin")
+	})
+	app.Post("/users", testEmptyHandler)
+
+	testCases := []struct {
+		target  string
+		headers map[string]string
+		code    int
+		body    string
+	}{
+		// requests without Accept header accept all media types
+		{target: "/users", code: StatusOK, body: "users v2"},
+		{target: "/users", headers: map[string]string{HeaderAccept: "application/json"}, code: StatusOK, body: "users v1"},
+		{target: "/users", headers: map[string]string{HeaderAccept: "text/html"}, code: StatusNotAcceptable, body: "Not Acceptable"},
+		{target: "/admin", headers: map[string]string{"X-Admin": "true", HeaderAccept: "text/html"}, code: StatusNotAcceptable, body: "Not Acceptable"},
+		{target: "/admin", headers: map[string]string{HeaderAccept: "text/html"}, code: StatusNotFound, body: "Cannot GET /admin"},
+		{target: "/admin", headers: map[string]string{"X-Admin": "true"}, code: StatusOK, body: "admin"},
+	}
+
+	for _, tc := range testCases {
+		code, body := testConditionRequest(t, app, MethodGet, tc.target, tc.headers)
+		require.Equal(t, tc.code, code, tc.target)
+		require.Equal(t, tc.body, body, tc.target)
+	}
+}
+
+// go test -run Test_Group_When
+func Test_Group_When(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	api := app.Group("/api")
+	v2 := api.When(MatchHeader("X-API-Version", "2"))
+	v2.Use(func(c Ctx) error {
+		c.Set("X-Version", "2")
+		return c.Next()
+	})
+	v2.Get("/users", func(c Ctx) error {
+		return c.SendString("v2 users")
+	}).Name("users.v2")
+	v2.Get("/users", func(c Ctx) error {
+		return c.SendString("unreachable")
+	})
+	v2.Group("/admin").When(MatchQuery("debug")).Get("/stats", func(c Ctx) error {
+		return c.SendString("v2 debug stats")
+	})
+	api.Get("/users", func(c Ctx) error {
+		return c.SendString("v1 users")
+	}).Name("users.v1")
+
+	sub := New()
+	sub.Get("/health", func(c Ctx) error {
+		return c.SendString("v2 health")
+	})
+	v2.Use("/sub", sub)
+
+	testCases := []struct {
+		target  string
+		headers map[string]string
+		code    int
+		body    string
+		version string
+	}{
+		{target: "/api/users", code: StatusOK, body: "v1 users"},
+		{target: "/api/users", headers: map[string]string{"X-API-Version": "2"}, code: StatusOK, body: "v2 users", version: "2"},
+		{target: "/api/admin/stats?debug", headers: map[string]string{"X-API-Version": "2"}, code: StatusOK, body: "v2 debug stats", version: "2"},
+		{target: "/api/admin/stats", headers: map[string]string{"X-API-Version": "2"}, code: StatusNotFound, body: "Cannot GET /api/admin/stats", version: "2"},
+		{target: "/api/admin/stats?debug", code: StatusNotFound, body: "Cannot GET /api/admin/stats"},
+		{target: "/api/sub/health", headers: map[string]string{"X-API-Version": "2"}, code: StatusOK, body: "v2 health", version: "2"},
+		{target: "/api/sub/health", code: StatusNotFound, body: "Cannot GET /api/sub/health"},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(MethodGet, tc.target, http.NoBody)
+		for key, value := range tc.headers {
+			req.Header.Set(key, value)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err, "app.Test(req)")
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, tc.code, resp.StatusCode, tc.target)
+		require.Equal(t, tc.body, string(body), tc.target)
+		require.Equal(t, tc.version, resp.Header.Get("X-Version"), tc.target)
+	}
+
+	require.Equal(t, "/api/users", app.GetRoute("users.v1").Path)
+	require.Equal(t, "/api/users", app.GetRoute("users.v2").Path)
+	require.Len(t, app.GetRoute("users.v2").Handlers, 2)
+}
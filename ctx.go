@@ -20,6 +20,8 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/gofiber/fiber/v3/internal/entitytag"
+	"github.com/gofiber/fiber/v3/log"
 	"github.com/gofiber/utils/v2"
 	"github.com/valyala/bytebufferpool"
 	"github.com/valyala/fasthttp"
@@ -52,7 +54,7 @@ type DefaultCtx struct {
 	pathBuffer          []byte               // HTTP path buffer
 	detectionPath       string               // Route detection path                                  -> string copy from detectionPathBuffer
 	detectionPathBuffer []byte               // HTTP detectionPath buffer
-	treePath            string               // Path for the search in the tree
+	treeStack           []*routeTree         // Route trees of the app when the request started
 	pathOriginal        string               // Original HTTP path
 	values              [maxParams]string    // Route parameter values
 	fasthttp            *fasthttp.RequestCtx // Reference to *fasthttp.RequestCtx
@@ -313,6 +315,34 @@ func (c *DefaultCtx) SetUserContext(ctx context.Context) {
 	c.fasthttp.SetUserValue(userContextKey, ctx)
 }
 
+// LogContext returns the UserContext carrying the path of the matched route and the
+// trace ID of a W3C traceparent header as log fields, see log.WithContext.
+// Unlike Ctx, the returned context can be used after the handler returned.
+func (c *DefaultCtx) LogContext() context.Context {
+	route := c.Route().Path
+	if c.route == nil {
+		// the fallback route refers to the request
+		route = utils.CopyString(route)
+	}
+	fields := make([]any, 0, 4) //nolint:gomnd // Two key-value pairs
+	fields = append(fields, "route", route)
+	if traceID := traceIDFromHeader(c.Get(HeaderTraceparent)); traceID != "" {
+		fields = append(fields, "trace_id", traceID)
+	}
+	return log.WithFields(c.UserContext(), fields...)
+}
+
+// traceIDFromHeader returns a copy of the trace ID of the W3C traceparent header, or an empty string if it is invalid.
+// https://www.w3.org/TR/trace-context/#traceparent-header
+func traceIDFromHeader(traceparent string) string {
+	// version "-" trace-id "-" parent-id "-" trace-flags
+	const traceparentLen = 55
+	if len(traceparent) < traceparentLen || traceparent[2] != '-' || traceparent[35] != '-' {
+		return ""
+	}
+	return utils.CopyString(traceparent[3:35])
+}
+
 // Cookie sets a cookie by passing a cookie struct.
 func (c *DefaultCtx) Cookie(cookie *Cookie) {
 	fcookie := fasthttp.AcquireCookie()
@@ -535,6 +565,67 @@ func (c *DefaultCtx) Fresh() bool {
 	return true
 }
 
+// CheckPreconditions evaluates the If-Match, If-Unmodified-Since, If-None-Match and
+// If-Modified-Since request headers against the entity tag and last modification time
+// of the selected representation, following the precedence of RFC 9110 section 13.2.2.
+// The ETag and Last-Modified response headers are set from the given values.
+// If a precondition fails, the response is prepared as 304 Not Modified or
+// 412 Precondition Failed and false is returned, the handler should then return without
+// performing the request.
+// An empty etag and a zero lastModified mean that the target resource has no current representation.
+func (c *DefaultCtx) CheckPreconditions(etag string, lastModified time.Time) bool {
+	exists := etag != "" || !lastModified.IsZero()
+	if etag != "" {
+		c.Set(HeaderETag, etag)
+	}
+	if !lastModified.IsZero() {
+		lastModified = lastModified.UTC().Truncate(time.Second)
+		c.Set(HeaderLastModified, lastModified.Format(http.TimeFormat))
+	}
+
+	// Step 1 and 2: If-Match or If-Unmodified-Since
+	if ifMatch := c.Get(HeaderIfMatch); ifMatch != "" {
+		if !exists || !entitytag.MatchList(utils.UnsafeBytes(ifMatch), utils.UnsafeBytes(etag), false) {
+			return c.preconditionFailed(StatusPreconditionFailed)
+		}
+	} else if unmodifiedSince := c.Get(HeaderIfUnmodifiedSince); unmodifiedSince != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(unmodifiedSince); err == nil && lastModified.After(t) {
+			return c.preconditionFailed(StatusPreconditionFailed)
+		}
+	}
+
+	method := c.Method()
+	isGetOrHead := method == MethodGet || method == MethodHead
+
+	// Step 3 and 4: If-None-Match or If-Modified-Since
+	if noneMatch := c.Get(HeaderIfNoneMatch); noneMatch != "" {
+		if exists && entitytag.MatchList(utils.UnsafeBytes(noneMatch), utils.UnsafeBytes(etag), true) {
+			if isGetOrHead {
+				return c.preconditionFailed(StatusNotModified)
+			}
+			return c.preconditionFailed(StatusPreconditionFailed)
+		}
+	} else if modifiedSince := c.Get(HeaderIfModifiedSince); modifiedSince != "" && isGetOrHead && !lastModified.IsZero() {
+		if t, err := http.ParseTime(modifiedSince); err == nil && !lastModified.After(t) {
+			return c.preconditionFailed(StatusNotModified)
+		}
+	}
+
+	return true
+}
+
+// preconditionFailed discards the response body and sets the given status.
+// It always returns false.
+func (c *DefaultCtx) preconditionFailed(status int) bool {
+	c.fasthttp.Response.ResetBody()
+	if status == StatusNotModified {
+		c.Status(status)
+	} else {
+		_ = c.SendStatus(status) //nolint:errcheck // It is fine to ignore the error here
+	}
+	return false
+}
+
 // Get returns the HTTP request header specified by field.
 // Field names are case-insensitive
 // Returned value is only valid within the handler. Do not store any references.
@@ -817,6 +908,21 @@ func (c *DefaultCtx) Locals(key any, value ...any) any {
 	return value[0]
 }
 
+// Locals is the type-safe variant of Ctx.Locals. It returns the value stored
+// under the key, or the zero value of V if there is none or it has another type.
+// If a value is given, it is stored under the key.
+//
+//	fiber.Locals[*User](c, "user", user)
+//	user := fiber.Locals[*User](c, "user")
+func Locals[V any](c Ctx, key any, value ...V) V {
+	if len(value) > 0 {
+		c.Locals(key, value[0])
+		return value[0]
+	}
+	v, _ := c.Locals(key).(V) //nolint:errcheck // The zero value is returned for other types
+	return v
+}
+
 // Location sets the response Location HTTP header to the specified path parameter.
 func (c *DefaultCtx) Location(path string) {
 	c.setCanonical(HeaderLocation, path)
@@ -941,6 +1047,20 @@ func (c *DefaultCtx) ParamsInt(key string, defaultValue ...int) (int, error) {
 	return value, nil
 }
 
+// Params is the typed variant of Ctx.Params. The route parameter is converted
+// to V, see parseValue for the supported types. If the parameter is empty or
+// cannot be converted to V, the default value is returned, or the zero value
+// of V if none is given. A missing parameter can't be told apart from an
+// invalid one, use Ctx.Params to check the raw value.
+//
+//	GET /users/42
+//	fiber.Params[int](c, "id") == 42
+//	fiber.Params[uint8](c, "id") == 42
+//	fiber.Params[bool](c, "id", true) == true
+func Params[V any](c Ctx, key string, defaultValue ...V) V {
+	return parseValueOrDefault(c.Params(key), defaultValue)
+}
+
 // Path returns the path part of the request URL.
 // Optionally, you could override the path.
 func (c *DefaultCtx) Path(override ...string) string {
@@ -1100,6 +1220,21 @@ func (c *DefaultCtx) QueryFloat(key string, defaultValue ...float64) float64 {
 	return value
 }
 
+// Query is the typed variant of Ctx.Query. The query parameter is converted
+// to V, see parseValue for the supported types. If the parameter is empty or
+// cannot be converted to V, the default value is returned, or the zero value
+// of V if none is given. A missing parameter can't be told apart from an
+// invalid one, use Ctx.Query to check the raw value.
+//
+//	GET /?limit=20&timeout=5s&since=2024-01-02T15:04:05Z&debug
+//	fiber.Query[int](c, "limit", 10) == 20
+//	fiber.Query[time.Duration](c, "timeout") == 5 * time.Second
+//	fiber.Query[time.Time](c, "since") == time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+//	fiber.Query[bool](c, "debug", true) == true
+func Query[V any](c Ctx, key string, defaultValue ...V) V {
+	return parseValueOrDefault(c.Query(key), defaultValue)
+}
+
 // Range returns a struct containing the type and a slice of ranges.
 func (c *DefaultCtx) Range(size int) (Range, error) {
 	var (
@@ -1167,6 +1302,31 @@ func (c *DefaultCtx) Range(size int) (Range, error) {
 	return rangeData, nil
 }
 
+// IfRange evaluates the If-Range request header against the entity tag and last
+// modification time of the selected representation. It returns false if the Range
+// header must be ignored and the full representation should be sent.
+// If-Range only matches strong validators, a date is compared to the exact last modification time.
+func (c *DefaultCtx) IfRange(etag string, lastModified time.Time) bool {
+	ifRange := c.Get(HeaderIfRange)
+	if ifRange == "" {
+		return true
+	}
+	if c.Method() != MethodGet || c.Get(HeaderRange) == "" {
+		return false
+	}
+
+	// Entity tags are always quoted, optionally with a weak prefix
+	if ifRange[0] == '"' || strings.HasPrefix(ifRange, "W/") {
+		return etag != "" && matchEtagStrong(ifRange, etag)
+	}
+
+	t, err := http.ParseTime(ifRange)
+	if err != nil || lastModified.IsZero() {
+		return false
+	}
+	return lastModified.UTC().Truncate(time.Second).Equal(t)
+}
+
 // Redirect returns the Redirect reference.
 // Use Redirect().Status() to set custom redirection status code.
 // If status is not specified, status defaults to 302 Found.
@@ -1193,11 +1353,32 @@ func (c *DefaultCtx) BindVars(vars Map) error {
 // getLocationFromRoute get URL location from route using parameters
 func (c *DefaultCtx) getLocationFromRoute(route Route, params Map) (string, error) {
 	buf := bytebufferpool.Get()
-	for _, segment := range route.routeParser.segs {
+	// routes of a domain get an absolute URL with the scheme of the request
+	if route.domain != nil {
+		_, err := buf.WriteString(c.Scheme() + "://")
+		if err != nil {
+			return "", fmt.Errorf("failed to write string: %w", err)
+		}
+		if err := c.writeRouteSegments(buf, route.domain.raw.segs, params); err != nil {
+			return "", err
+		}
+	}
+	if err := c.writeRouteSegments(buf, route.routeParser.segs, params); err != nil {
+		return "", err
+	}
+	location := buf.String()
+	// release buffer
+	bytebufferpool.Put(buf)
+	return location, nil
+}
+
+// writeRouteSegments writes the route segments to the buffer, replacing the parameters with the given values
+func (c *DefaultCtx) writeRouteSegments(buf *bytebufferpool.ByteBuffer, segs []*routeSegment, params Map) error {
+	for _, segment := range segs {
 		if !segment.IsParam {
 			_, err := buf.WriteString(segment.Const)
 			if err != nil {
-				return "", fmt.Errorf("failed to write string: %w", err)
+				return fmt.Errorf("failed to write string: %w", err)
 			}
 			continue
 		}
@@ -1208,18 +1389,16 @@ func (c *DefaultCtx) getLocationFromRoute(route Route, params Map) (string, erro
 			if isSame || isGreedy {
 				_, err := buf.WriteString(utils.ToString(val))
 				if err != nil {
-					return "", fmt.Errorf("failed to write string: %w", err)
+					return fmt.Errorf("failed to write string: %w", err)
 				}
 			}
 		}
 	}
-	location := buf.String()
-	// release buffer
-	bytebufferpool.Put(buf)
-	return location, nil
+	return nil
 }
 
-// GetRouteURL generates URLs to named routes, with parameters. URLs are relative, for example: "/user/1831"
+// GetRouteURL generates URLs to named routes, with parameters. URLs are relative, for example: "/user/1831",
+// unless the route was registered with Domain: "https://api.example.com/user/1831"
 func (c *DefaultCtx) GetRouteURL(routeName string, params Map) (string, error) {
 	return c.getLocationFromRoute(c.App().GetRoute(routeName), params)
 }
@@ -1597,14 +1776,6 @@ func (c *DefaultCtx) configDependentPaths() {
 		c.detectionPathBuffer = bytes.TrimRight(c.detectionPathBuffer, "/")
 	}
 	c.detectionPath = c.app.getString(c.detectionPathBuffer)
-
-	// Define the path for dividing routes into areas for fast tree detection, so that fewer routes need to be traversed,
-	// since the first three characters area select a list of routes
-	c.treePath = c.treePath[0:0]
-	const maxDetectionPaths = 3
-	if len(c.detectionPath) >= maxDetectionPaths {
-		c.treePath = c.detectionPath[:maxDetectionPaths]
-	}
 }
 
 // IsProxyTrusted checks trustworthiness of remote ip.
@@ -11,6 +11,7 @@ import (
 	"io"
 	"mime/multipart"
 	"sync"
+	"time"
 
 	"github.com/valyala/fasthttp"
 )
@@ -63,6 +64,11 @@ type Ctx interface {
 	// SetUserContext sets a context implementation by user.
 	SetUserContext(ctx context.Context)
 
+	// LogContext returns the UserContext carrying the path of the matched route and the
+	// trace ID of a W3C traceparent header as log fields, see log.WithContext.
+	// Unlike Ctx, the returned context can be used after the handler returned.
+	LogContext() context.Context
+
 	// Cookie sets a cookie by passing a cookie struct.
 	Cookie(cookie *Cookie)
 
@@ -122,6 +128,15 @@ type Ctx interface {
 	// https://github.com/jshttp/fresh/blob/10e0471669dbbfbfd8de65bc6efac2ddd0bfa057/index.js#L33
 	Fresh() bool
 
+	// CheckPreconditions evaluates the If-Match, If-Unmodified-Since, If-None-Match and
+	// If-Modified-Since request headers against the entity tag and last modification time
+	// of the selected representation, following the precedence of RFC 9110 section 13.2.2.
+	// The ETag and Last-Modified response headers are set from the given values.
+	// If a precondition fails, the response is prepared as 304 Not Modified or
+	// 412 Precondition Failed and false is returned, the handler should then return without
+	// performing the request.
+	CheckPreconditions(etag string, lastModified time.Time) bool
+
 	// Get returns the HTTP request header specified by field.
 	// Field names are case-insensitive
 	// Returned value is only valid within the handler. Do not store any references.
@@ -298,6 +313,11 @@ type Ctx interface {
 	// Range returns a struct containing the type and a slice of ranges.
 	Range(size int) (rangeData Range, err error)
 
+	// IfRange evaluates the If-Range request header against the entity tag and last
+	// modification time of the selected representation. It returns false if the Range
+	// header must be ignored and the full representation should be sent.
+	IfRange(etag string, lastModified time.Time) bool
+
 	// Redirect returns the Redirect reference.
 	// Use Redirect().Status() to set custom redirection status code.
 	// If status is not specified, status defaults to 302 Found.
@@ -308,7 +328,8 @@ type Ctx interface {
 	// Variables are read by the Render method and may be overwritten.
 	BindVars(vars Map) error
 
-	// GetRouteURL generates URLs to named routes, with parameters. URLs are relative, for example: "/user/1831"
+	// GetRouteURL generates URLs to named routes, with parameters. URLs are relative, for example: "/user/1831",
+	// unless the route was registered with Domain: "https://api.example.com/user/1831"
 	GetRouteURL(routeName string, params Map) (string, error)
 
 	// Render a template with data and sends a text/html response.
@@ -421,11 +442,12 @@ type CustomCtx interface {
 	// Methods to use with next stack.
 	getMethodINT() int
 	getIndexRoute() int
-	getTreePath() string
+	getTreeStack() []*routeTree
 	getDetectionPath() string
 	getPathOriginal() string
 	getValues() *[maxParams]string
 	getMatched() bool
+	getRoute() *Route
 	setIndexHandler(handler int)
 	setIndexRoute(route int)
 	setMatched(matched bool)
@@ -504,11 +526,15 @@ func (c *DefaultCtx) Reset(fctx *fasthttp.RequestCtx) {
 
 	// Prettify path
 	c.configDependentPaths()
+
+	// Keep the route trees for the whole request
+	c.treeStack = *c.app.treeStack.Load()
 }
 
 // Release is a method to reset context fields when to use ReleaseCtx()
 func (c *DefaultCtx) release() {
 	c.route = nil
+	c.treeStack = nil
 	c.fasthttp = nil
 	c.bind = nil
 	c.redirectionMessages = c.redirectionMessages[:0]
@@ -533,6 +559,9 @@ func (c *DefaultCtx) setReq(fctx *fasthttp.RequestCtx) {
 
 	// Prettify path
 	c.configDependentPaths()
+
+	// Keep the route trees for the whole request
+	c.treeStack = *c.app.treeStack.Load()
 }
 
 // Methods to use with next stack.
@@ -544,8 +573,8 @@ func (c *DefaultCtx) getIndexRoute() int {
 	return c.indexRoute
 }
 
-func (c *DefaultCtx) getTreePath() string {
-	return c.treePath
+func (c *DefaultCtx) getTreeStack() []*routeTree {
+	return c.treeStack
 }
 
 func (c *DefaultCtx) getDetectionPath() string {
@@ -564,6 +593,10 @@ func (c *DefaultCtx) getMatched() bool {
 	return c.matched
 }
 
+func (c *DefaultCtx) getRoute() *Route {
+	return c.route
+}
+
 func (c *DefaultCtx) setIndexHandler(handler int) {
 	c.indexHandler = handler
 }
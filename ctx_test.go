@@ -18,6 +18,7 @@ import (
 	"io"
 	"mime/multipart"
 	"net"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
@@ -28,6 +29,7 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v3/internal/storage/memory"
+	"github.com/gofiber/fiber/v3/log"
 	"github.com/gofiber/utils/v2"
 	"github.com/stretchr/testify/require"
 	"github.com/valyala/bytebufferpool"
@@ -599,6 +601,34 @@ func Test_Ctx_SetUserContext(t *testing.T) {
 	require.Equal(t, testValue, c.UserContext().Value(testKey))
 }
 
+// go test -run Test_Ctx_LogWithContext
+func Test_Ctx_LogWithContext(t *testing.T) {
+	t.Parallel()
+	app := New()
+
+	var ctx context.Context
+	app.Get("/users/:id", func(c Ctx) error {
+		c.SetUserContext(log.WithFields(c.UserContext(), "user", c.Params("id")))
+		ctx = c.LogContext()
+		return nil
+	})
+
+	req := httptest.NewRequest(MethodGet, "/users/42", nil)
+	req.Header.Set(HeaderTraceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, StatusOK, resp.StatusCode)
+	// The context is used after the handler returned
+	require.Equal(t, []any{"user", "42", "route", "/users/:id", "trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"}, log.FieldsFromContext(ctx))
+
+	// Invalid traceparent headers are ignored
+	req = httptest.NewRequest(MethodGet, "/users/42", nil)
+	req.Header.Set(HeaderTraceparent, "invalid")
+	_, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, []any{"user", "42", "route", "/users/:id"}, log.FieldsFromContext(ctx))
+}
+
 // go test -run Test_Ctx_UserContext_Multiple_Requests
 func Test_Ctx_UserContext_Multiple_Requests(t *testing.T) {
 	t.Parallel()
@@ -1122,6 +1152,103 @@ func Benchmark_Ctx_Fresh_WithNoCache(b *testing.B) {
 	}
 }
 
+// go test -run Test_Ctx_CheckPreconditions
+func Test_Ctx_CheckPreconditions(t *testing.T) {
+	t.Parallel()
+	app := New()
+
+	lastModified := time.Date(2023, time.October, 1, 12, 0, 0, 0, time.UTC)
+	before := lastModified.Add(-time.Hour).Format(http.TimeFormat)
+	after := lastModified.Add(time.Hour).Format(http.TimeFormat)
+
+	testCases := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		etag    string
+		ok      bool
+		status  int
+	}{
+		{name: "unconditional", method: MethodGet, etag: `"v1"`, ok: true},
+		{name: "if-match", method: MethodPut, headers: map[string]string{HeaderIfMatch: `"v0", "v1"`}, etag: `"v1"`, ok: true},
+		{name: "if-match star", method: MethodPut, headers: map[string]string{HeaderIfMatch: `*`}, etag: `"v1"`, ok: true},
+		{name: "if-match star missing", method: MethodPut, headers: map[string]string{HeaderIfMatch: `*`}, status: StatusPreconditionFailed},
+		{name: "if-match quoted comma", method: MethodPut, headers: map[string]string{HeaderIfMatch: `"v0,v1"`}, etag: `"v1"`, status: StatusPreconditionFailed},
+		{name: "if-match mismatch", method: MethodPut, headers: map[string]string{HeaderIfMatch: `"v0"`}, etag: `"v1"`, status: StatusPreconditionFailed},
+		{name: "if-match weak", method: MethodPut, headers: map[string]string{HeaderIfMatch: `W/"v1"`}, etag: `W/"v1"`, status: StatusPreconditionFailed},
+		{name: "if-unmodified-since", method: MethodPut, headers: map[string]string{HeaderIfUnmodifiedSince: after}, etag: `"v1"`, ok: true},
+		{name: "if-unmodified-since modified", method: MethodPut, headers: map[string]string{HeaderIfUnmodifiedSince: before}, etag: `"v1"`, status: StatusPreconditionFailed},
+		{name: "if-match takes precedence", method: MethodPut, headers: map[string]string{HeaderIfMatch: `"v1"`, HeaderIfUnmodifiedSince: before}, etag: `"v1"`, ok: true},
+		{name: "if-none-match", method: MethodGet, headers: map[string]string{HeaderIfNoneMatch: `W/"v1"`}, etag: `"v1"`, status: StatusNotModified},
+		{name: "if-none-match star", method: MethodPut, headers: map[string]string{HeaderIfNoneMatch: `*`}, etag: `"v1"`, status: StatusPreconditionFailed},
+		{name: "if-none-match star missing", method: MethodPut, headers: map[string]string{HeaderIfNoneMatch: `*`}, ok: true},
+		{name: "if-none-match mismatch", method: MethodGet, headers: map[string]string{HeaderIfNoneMatch: `"v0"`}, etag: `"v1"`, ok: true},
+		{name: "if-modified-since", method: MethodGet, headers: map[string]string{HeaderIfModifiedSince: after}, etag: `"v1"`, status: StatusNotModified},
+		{name: "if-modified-since modified", method: MethodGet, headers: map[string]string{HeaderIfModifiedSince: before}, etag: `"v1"`, ok: true},
+		{name: "if-modified-since ignored for put", method: MethodPut, headers: map[string]string{HeaderIfModifiedSince: after}, etag: `"v1"`, ok: true},
+		{name: "if-none-match takes precedence", method: MethodGet, headers: map[string]string{HeaderIfNoneMatch: `"v0"`, HeaderIfModifiedSince: after}, etag: `"v1"`, ok: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			fctx := &fasthttp.RequestCtx{}
+			fctx.Request.Header.SetMethod(tc.method)
+			c := app.NewCtx(fctx)
+			for k, v := range tc.headers {
+				c.Request().Header.Set(k, v)
+			}
+			require.NoError(t, c.SendString("body"))
+
+			modTime := lastModified
+			if tc.etag == "" {
+				modTime = time.Time{}
+			}
+
+			require.Equal(t, tc.ok, c.CheckPreconditions(tc.etag, modTime))
+			require.Equal(t, tc.etag, string(c.Response().Header.Peek(HeaderETag)))
+			if tc.ok {
+				require.Equal(t, StatusOK, c.Response().StatusCode())
+				require.Equal(t, "body", string(c.Response().Body()))
+				return
+			}
+			require.Equal(t, tc.status, c.Response().StatusCode())
+			require.NotEqual(t, "body", string(c.Response().Body()))
+		})
+	}
+}
+
+// go test -run Test_Ctx_IfRange
+func Test_Ctx_IfRange(t *testing.T) {
+	t.Parallel()
+	app := New()
+	c := app.NewCtx(&fasthttp.RequestCtx{})
+
+	lastModified := time.Date(2023, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+	require.True(t, c.IfRange(`"v1"`, lastModified))
+
+	c.Request().Header.Set(HeaderIfRange, `"v1"`)
+	require.False(t, c.IfRange(`"v1"`, lastModified))
+
+	c.Request().Header.Set(HeaderRange, "bytes=0-10")
+	require.True(t, c.IfRange(`"v1"`, lastModified))
+	require.False(t, c.IfRange(`"v2"`, lastModified))
+	require.False(t, c.IfRange(`W/"v1"`, lastModified))
+
+	c.Request().Header.Set(HeaderIfRange, `W/"v1"`)
+	require.False(t, c.IfRange(`W/"v1"`, lastModified))
+
+	c.Request().Header.Set(HeaderIfRange, lastModified.Format(http.TimeFormat))
+	require.True(t, c.IfRange(`"v1"`, lastModified))
+	require.False(t, c.IfRange(`"v1"`, lastModified.Add(time.Hour)))
+	require.False(t, c.IfRange(`"v1"`, time.Time{}))
+
+	c.Request().Header.Set(HeaderIfRange, "invalid")
+	require.False(t, c.IfRange(`"v1"`, lastModified))
+}
+
 // go test -run Test_Ctx_Get
 func Test_Ctx_Get(t *testing.T) {
 	t.Parallel()
@@ -1755,6 +1882,28 @@ func Test_Ctx_Locals(t *testing.T) {
 	require.Equal(t, StatusOK, resp.StatusCode, "Status code")
 }
 
+// go test -run Test_Locals_Generic
+func Test_Locals_Generic(t *testing.T) {
+	t.Parallel()
+	type user struct{ name string }
+	app := New()
+	app.Use(func(c Ctx) error {
+		Locals[*user](c, "user", &user{name: "john"})
+		Locals(c, "id", 42)
+		return c.Next()
+	})
+	app.Get("/test", func(c Ctx) error {
+		require.Equal(t, "john", Locals[*user](c, "user").name)
+		require.Equal(t, 42, Locals[int](c, "id"))
+		require.Equal(t, "", Locals[string](c, "id"))
+		require.Nil(t, Locals[*user](c, "unknown"))
+		return nil
+	})
+	resp, err := app.Test(httptest.NewRequest(MethodGet, "/test", nil))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, StatusOK, resp.StatusCode, "Status code")
+}
+
 // go test -run Test_Ctx_Method
 func Test_Ctx_Method(t *testing.T) {
 	t.Parallel()
@@ -1971,6 +2120,26 @@ func Test_Ctx_Params(t *testing.T) {
 	require.Equal(t, StatusOK, resp.StatusCode, "Status code")
 }
 
+// go test -run Test_Params_Generic
+func Test_Params_Generic(t *testing.T) {
+	t.Parallel()
+	app := New()
+	app.Get("/test/:id/:flag/:name?", func(c Ctx) error {
+		require.Equal(t, 255, Params[int](c, "id"))
+		require.Equal(t, uint8(255), Params[uint8](c, "id"))
+		require.Equal(t, int8(-1), Params[int8](c, "id", -1))
+		require.Equal(t, float32(255), Params[float32](c, "id"))
+		require.True(t, Params[bool](c, "flag"))
+		require.Equal(t, uint(0), Params[uint](c, "flag"))
+		require.Equal(t, "john", Params(c, "name", "john"))
+		require.Equal(t, 7, Params(c, "unknown", 7))
+		return nil
+	})
+	resp, err := app.Test(httptest.NewRequest(MethodGet, "/test/255/true", nil))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, StatusOK, resp.StatusCode, "Status code")
+}
+
 func Test_Ctx_Params_Case_Sensitive(t *testing.T) {
 	t.Parallel()
 	app := New(Config{CaseSensitive: true})
@@ -2277,6 +2446,56 @@ func Test_Ctx_QueryFloat(t *testing.T) {
 	require.Equal(t, float64(0), c.QueryFloat("id"))
 }
 
+type testLevel int
+
+type testLevelName string
+
+func (l *testLevelName) UnmarshalText(text []byte) error {
+	if len(text) > 5 {
+		return errors.New("level name too long")
+	}
+	*l = testLevelName(strings.ToUpper(string(text)))
+	return nil
+}
+
+// go test -run Test_Query_Generic
+func Test_Query_Generic(t *testing.T) {
+	t.Parallel()
+	app := New()
+	c := app.NewCtx(&fasthttp.RequestCtx{})
+
+	c.Request().URI().SetQueryString("name=alex&amount=32.23&id=&big=300&neg=-5&ok=true" +
+		"&timeout=1m30s&since=2024-01-02T15:04:05Z&ip=10.0.0.1&level=3&level_name=debug&long=verbose")
+
+	require.Equal(t, "alex", Query[string](c, "name"))
+	require.Equal(t, "bob", Query(c, "id", "bob"))
+	require.Equal(t, 32.23, Query[float64](c, "amount"))
+	require.Equal(t, float32(32.23), Query[float32](c, "amount"))
+	require.Equal(t, 0, Query[int](c, "amount"))
+	require.Equal(t, 300, Query[int](c, "big"))
+	require.Equal(t, int16(300), Query[int16](c, "big"))
+	require.Equal(t, int64(300), Query[int64](c, "big"))
+	require.Equal(t, int8(1), Query(c, "big", int8(1)), "out of range")
+	require.Equal(t, uint8(2), Query(c, "big", uint8(2)), "out of range")
+	require.Equal(t, int32(-5), Query[int32](c, "neg"))
+	require.Equal(t, uint64(3), Query(c, "neg", uint64(3)), "negative unsigned")
+	require.True(t, Query[bool](c, "ok"))
+	require.True(t, Query(c, "name", true))
+	require.False(t, Query[bool](c, "id"))
+	require.Equal(t, 90*time.Second, Query[time.Duration](c, "timeout"))
+	require.Equal(t, time.Second, Query(c, "amount", time.Second))
+	require.Equal(t, time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), Query[time.Time](c, "since").UTC())
+	require.True(t, Query[time.Time](c, "name").IsZero())
+	require.Equal(t, net.IPv4(10, 0, 0, 1), Query[net.IP](c, "ip"))
+	require.Equal(t, testLevel(3), Query[testLevel](c, "level"))
+	require.Equal(t, testLevelName("DEBUG"), Query[testLevelName](c, "level_name"))
+	require.Equal(t, testLevelName("INFO"), Query(c, "long", testLevelName("INFO")))
+	require.Nil(t, Query[[]int](c, "level"), "unsupported type")
+	require.Equal(t, []int{1}, Query(c, "level", []int{1}), "unsupported type")
+	var n *int
+	require.Equal(t, n, Query[*int](c, "level"), "unsupported type")
+}
+
 // go test -run Test_Ctx_Range
 func Test_Ctx_Range(t *testing.T) {
 	t.Parallel()
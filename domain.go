@@ -0,0 +1,133 @@
+// ⚡️ Fiber is an Express inspired web framework written in Go with ☕️
+// 🤖 Github Repository: https://github.com/gofiber/fiber
+// 📌 API Documentation: https://docs.gofiber.io
+
+package fiber
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/utils/v2"
+)
+
+// routeDomain is the parsed host pattern of routes registered with Domain
+type routeDomain struct {
+	pattern string      // Lowercased host pattern
+	parser  routeParser // Parameter parser of the lowercased pattern
+	raw     routeParser // Parsed pattern as registered, with case sensitive param keys
+}
+
+// Domain is used to define routes which only match requests for the given
+// hostname, like a Group without prefix.
+//
+//	api := app.Domain("api.example.com")
+//	api.Get("/users", handler)
+//
+// The pattern can contain parameters like route paths, which are available
+// with Params like the ones of the path.
+//
+//	tenant := app.Domain(":tenant.example.com")
+//	tenant.Get("/", func(c fiber.Ctx) error {
+//	     return c.SendString(c.Params("tenant"))
+//	})
+func (app *App) Domain(host string) Router {
+	grp := &Group{app: app, domain: parseDomain(host, app.config.CustomConstraints)}
+	if err := app.hooks.executeOnGroupHooks(*grp); err != nil {
+		panic(err)
+	}
+
+	return grp
+}
+
+// Domain is used to define routes which only match requests for the given
+// hostname, with the prefix of the group.
+//
+//	v1 := app.Group("/v1")
+//	v1.Domain("api.example.com").Get("/users", handler)
+func (grp *Group) Domain(host string) Router {
+	newGrp := &Group{
+		Prefix:      grp.Prefix,
+		app:         grp.app,
+		parentGroup: grp,
+		domain:      parseDomain(host, grp.app.config.CustomConstraints),
+		conditions:  grp.conditions,
+	}
+	if err := grp.app.hooks.executeOnGroupHooks(*newGrp); err != nil {
+		panic(err)
+	}
+
+	return newGrp
+}
+
+// parseDomain parses the host pattern of a Domain router
+func parseDomain(host string, customConstraints []CustomConstraint) *routeDomain {
+	// Hostnames are case-insensitive and are matched without trailing dot
+	host = strings.TrimSuffix(host, ".")
+	if host == "" {
+		panic("domain: host pattern must not be empty")
+	}
+	raw := parseRoute(host, customConstraints...)
+
+	return &routeDomain{
+		pattern: utils.ToLower(host),
+		// the constraints of the parameters keep their case
+		parser: raw.foldCase(),
+		raw:    raw,
+	}
+}
+
+// paramsCount returns the number of parameters of the domain, the domain can be nil
+func (d *routeDomain) paramsCount() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.raw.params)
+}
+
+// matchDomain checks if the hostname of the request matches the domain of the
+// route. The values of the domain parameters are stored after the ones of the path.
+func (r *Route) matchDomain(c Ctx, params *[maxParams]string) bool {
+	if r.domain == nil {
+		return true
+	}
+	hostname := c.Hostname()
+	for i := 0; i < len(hostname); i++ {
+		if hostname[i] >= 'A' && hostname[i] <= 'Z' {
+			hostname = utils.ToLower(hostname)
+			break
+		}
+	}
+	hostname = strings.TrimSuffix(hostname, ".")
+
+	var values [maxParams]string
+	if !r.domain.parser.getMatch(hostname, hostname, &values, false) {
+		return false
+	}
+	count := r.domain.paramsCount()
+	copy(params[len(r.Params)-count:], values[:count])
+
+	return true
+}
+
+// domainPattern returns the host pattern of the route, or an empty string if
+// it matches all hosts
+func (r *Route) domainPattern() string {
+	if r.domain == nil {
+		return ""
+	}
+	return r.domain.pattern
+}
+
+// setDomain restricts the route to the given domain and adds its parameters
+func (r *Route) setDomain(domain *routeDomain) {
+	if domain == nil {
+		return
+	}
+	if len(r.Params)+len(domain.raw.params) > maxParams {
+		panic(fmt.Sprintf("domain: route %s with domain %s has more than %d parameters\n", r.Path, domain.pattern, maxParams))
+	}
+	r.domain = domain
+	params := make([]string, 0, len(r.Params)+len(domain.raw.params))
+	r.Params = append(append(params, r.Params...), domain.raw.params...)
+}
@@ -0,0 +1,245 @@
+// ⚡️ Fiber is an Express inspired web framework written in Go with ☕️
+// 🤖 Github Repository: https://github.com/gofiber/fiber
+// 📌 API Documentation: https://docs.gofiber.io
+
+//nolint:bodyclose // Much easier to just ignore memory leaks in tests
+package fiber
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testDomainRequest(t *testing.T, app *App, method, target string) (int, string) {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest(method, target, http.NoBody))
+	require.NoError(t, err, "app.Test(req)")
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
+
+// go test -run Test_App_Domain
+func Test_App_Domain(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	app.Domain("api.example.com").Get("/users", func(c Ctx) error {
+		return c.SendString("api users")
+	})
+	tenant := app.Domain(":tenant.example.com")
+	tenant.Get("/users/:id", func(c Ctx) error {
+		return c.SendString(c.Params("tenant") + " user " + c.Params("id"))
+	})
+	app.Domain(":sub.:tenant.example.com").Get("/", func(c Ctx) error {
+		return c.SendString(c.Params("sub") + "." + c.Params("tenant"))
+	})
+	app.Get("/users", func(c Ctx) error {
+		return c.SendString("users")
+	})
+
+	testCases := []struct {
+		target string
+		code   int
+		body   string
+	}{
+		{target: "http://api.example.com/users", code: StatusOK, body: "api users"},
+		{target: "http://API.Example.com:8080/users", code: StatusOK, body: "api users"},
+		{target: "http://www.example.com/users", code: StatusOK, body: "users"},
+		{target: "http://acme.example.com/users/42", code: StatusOK, body: "acme user 42"},
+		{target: "http://eu.acme.example.com/", code: StatusOK, body: "eu.acme"},
+		{target: "http://example.com/users/42", code: StatusNotFound, body: "Cannot GET /users/42"},
+		{target: "http://acme.example.org/users/42", code: StatusNotFound, body: "Cannot GET /users/42"},
+	}
+
+	for _, tc := range testCases {
+		code, body := testDomainRequest(t, app, MethodGet, tc.target)
+		require.Equal(t, tc.code, code, tc.target)
+		require.Equal(t, tc.body, body, tc.target)
+	}
+
+	for _, route := range app.GetRoutes(true) {
+		if route.Path == "/users/:id" {
+			require.Equal(t, []string{"id", "tenant"}, route.Params)
+		}
+	}
+	require.PanicsWithValue(t, "domain: host pattern must not be empty", func() {
+		app.Domain("")
+	})
+}
+
+// go test -run Test_App_Domain_MethodNotAllowed
+func Test_App_Domain_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	app.Domain("api.example.com").Post("/users", func(c Ctx) error {
+		return c.SendStatus(StatusCreated)
+	})
+	app.Domain("admin.example.com").Put("/users", func(c Ctx) error {
+		return c.SendStatus(StatusNoContent)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(MethodGet, "http://api.example.com/users", http.NoBody))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, StatusMethodNotAllowed, resp.StatusCode)
+	require.Equal(t, MethodPost, resp.Header.Get(HeaderAllow))
+
+	code, _ := testDomainRequest(t, app, MethodGet, "http://www.example.com/users")
+	require.Equal(t, StatusNotFound, code)
+}
+
+// go test -run Test_Group_Domain
+func Test_Group_Domain(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	v1 := app.Group("/v1", func(c Ctx) error {
+		c.Set("X-Version", "1")
+		return c.Next()
+	})
+	admin := v1.Domain("admin.example.com")
+	admin.Use(func(c Ctx) error {
+		c.Set("X-Admin", "true")
+		return c.Next()
+	})
+	admin.Group("/users").Get("/:id", func(c Ctx) error {
+		return c.SendString("admin user " + c.Params("id"))
+	})
+	admin.Route("/stats").Get(func(c Ctx) error {
+		return c.SendString("admin stats")
+	})
+	v1.Get("/users/:id", func(c Ctx) error {
+		return c.SendString("user " + c.Params("id"))
+	})
+
+	resp, err := app.Test(httptest.NewRequest(MethodGet, "http://admin.example.com/v1/users/1", http.NoBody))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, "1", resp.Header.Get("X-Version"))
+	require.Equal(t, "true", resp.Header.Get("X-Admin"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "admin user 1", string(body))
+
+	resp, err = app.Test(httptest.NewRequest(MethodGet, "http://www.example.com/v1/users/1", http.NoBody))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, "", resp.Header.Get("X-Admin"))
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "user 1", string(body))
+
+	_, body2 := testDomainRequest(t, app, MethodGet, "http://admin.example.com/v1/stats")
+	require.Equal(t, "admin stats", body2)
+	code, _ := testDomainRequest(t, app, MethodGet, "http://www.example.com/v1/stats")
+	require.Equal(t, StatusNotFound, code)
+}
+
+// go test -run Test_Group_Route_Name
+func Test_Group_Route_Name(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	grp := app.Group("/v1").Name("v1.")
+	grp.Route("/stats").Get(testEmptyHandler)
+	app.Name("stats")
+
+	// Routes of Route are not prefixed with the group name
+	require.Equal(t, "/v1/stats", app.GetRoute("stats").Path)
+	require.Equal(t, "", app.GetRoute("v1.stats").Path)
+}
+
+// go test -run Test_App_Domain_MaxParams
+func Test_App_Domain_MaxParams(t *testing.T) {
+	t.Parallel()
+
+	path := strings.Repeat("/:p", maxParams-1)
+	app := New()
+	app.Domain(":tenant.example.com").Get(path, testEmptyHandler)
+
+	require.PanicsWithValue(t, fmt.Sprintf("domain: route %s with domain :a.:b.example.com has more than %d parameters\n", path, maxParams), func() {
+		app.Domain(":a.:b.example.com").Get(path, testEmptyHandler)
+	})
+}
+
+// go test -run Test_App_Domain_Mount
+func Test_App_Domain_Mount(t *testing.T) {
+	t.Parallel()
+
+	api := New()
+	api.Get("/users", func(c Ctx) error {
+		return c.SendString(c.Params("tenant") + " users")
+	})
+	api.Domain("internal.example.com").Get("/health", func(c Ctx) error {
+		return c.SendString("ok")
+	})
+
+	app := New()
+	app.Domain(":tenant.example.com").Use("/api", api)
+	app.Use("/public", api)
+
+	testCases := []struct {
+		target string
+		code   int
+		body   string
+	}{
+		{target: "http://acme.example.com/api/users", code: StatusOK, body: "acme users"},
+		{target: "http://acme.example.org/api/users", code: StatusNotFound, body: "Cannot GET /api/users"},
+		{target: "http://internal.example.com/api/health", code: StatusOK, body: "ok"},
+		{target: "http://acme.example.com/api/health", code: StatusNotFound, body: "Cannot GET /api/health"},
+		{target: "http://example.org/public/users", code: StatusOK, body: " users"},
+		{target: "http://internal.example.com/public/health", code: StatusOK, body: "ok"},
+		{target: "http://example.org/public/health", code: StatusNotFound, body: "Cannot GET /public/health"},
+	}
+
+	for _, tc := range testCases {
+		code, body := testDomainRequest(t, app, MethodGet, tc.target)
+		require.Equal(t, tc.code, code, tc.target)
+		require.Equal(t, tc.body, body, tc.target)
+	}
+}
+
+// go test -run Test_App_Domain_GetRouteURL
+func Test_App_Domain_GetRouteURL(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	handler := func(c Ctx) error {
+		return c.SendString(c.Route().Name)
+	}
+	app.Domain("api.example.com").Get("/users/:id", handler).Name("api.user")
+	app.Domain(":Tenant.example.com").Name("tenant.").Get("/users/:id", handler).Name("user")
+	app.Get("/users/:id", handler).Name("user")
+	app.Get("/links", func(c Ctx) error {
+		tenant, err := c.GetRouteURL("tenant.user", Map{"Tenant": "acme", "id": 1})
+		if err != nil {
+			return err
+		}
+		api, err := c.GetRouteURL("api.user", Map{"id": 2})
+		if err != nil {
+			return err
+		}
+		user, err := c.GetRouteURL("user", Map{"id": 3})
+		if err != nil {
+			return err
+		}
+		return c.SendString(tenant + " " + api + " " + user)
+	})
+
+	_, body := testDomainRequest(t, app, MethodGet, "http://www.example.com/links")
+	require.Equal(t, "http://acme.example.com/users/1 http://api.example.com/users/2 /users/3", body)
+
+	_, body = testDomainRequest(t, app, MethodGet, "http://acme.example.com/users/1")
+	require.Equal(t, "tenant.user", body)
+	_, body = testDomainRequest(t, app, MethodGet, "http://api.example.com/users/1")
+	require.Equal(t, "api.user", body)
+	_, body = testDomainRequest(t, app, MethodGet, "http://example.org/users/1")
+	require.Equal(t, "user", body)
+}
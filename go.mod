@@ -3,6 +3,7 @@ module github.com/gofiber/fiber/v3
 go 1.20
 
 require (
+	github.com/andybalholm/brotli v1.1.0
 	github.com/gofiber/utils/v2 v2.0.0-beta.3
 	github.com/google/uuid v1.5.0
 	github.com/mattn/go-colorable v0.1.13
@@ -14,7 +15,6 @@ require (
 )
 
 require (
-	github.com/andybalholm/brotli v1.1.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/klauspost/compress v1.17.4 // indirect
 	github.com/philhofer/fwd v1.1.2 // indirect
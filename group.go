@@ -13,6 +13,8 @@ import (
 type Group struct {
 	app             *App
 	parentGroup     *Group
+	domain          *routeDomain
+	conditions      []RouteCondition
 	name            string
 	anyRouteDefined bool
 
@@ -172,7 +174,7 @@ func (grp *Group) Add(methods []string, path string, handler Handler, middleware
 
 // Static will create a file server serving static files
 func (grp *Group) Static(prefix, root string, config ...Static) Router {
-	grp.app.registerStatic(getGroupPath(grp.Prefix, prefix), root, config...)
+	grp.app.registerStatic(getGroupPath(grp.Prefix, prefix), root, grp, config...)
 	if !grp.anyRouteDefined {
 		grp.anyRouteDefined = true
 	}
@@ -197,7 +199,7 @@ func (grp *Group) Group(prefix string, handlers ...Handler) Router {
 	}
 
 	// Create new group
-	newGrp := &Group{Prefix: prefix, app: grp.app, parentGroup: grp}
+	newGrp := &Group{Prefix: prefix, app: grp.app, parentGroup: grp, domain: grp.domain, conditions: grp.conditions}
 	if err := grp.app.hooks.executeOnGroupHooks(*newGrp); err != nil {
 		panic(err)
 	}
@@ -209,7 +211,18 @@ func (grp *Group) Group(prefix string, handlers ...Handler) Router {
 // Uses Group method to define new sub-router.
 func (grp *Group) Route(path string) Register {
 	// Create new group
-	register := &Registering{app: grp.app, path: getGroupPath(grp.Prefix, path)}
+	register := &Registering{app: grp.app, group: grp.registerGroup(), path: getGroupPath(grp.Prefix, path)}
 
 	return register
 }
+
+// registerGroup returns the group for the routes registered with Route. It only
+// carries the domain and conditions of the group, so the route names are not
+// prefixed with the group name.
+func (grp *Group) registerGroup() *Group {
+	if grp.domain == nil && len(grp.conditions) == 0 {
+		return nil
+	}
+
+	return &Group{app: grp.app, domain: grp.domain, conditions: grp.conditions}
+}
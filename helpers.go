@@ -7,12 +7,14 @@ package fiber
 import (
 	"bytes"
 	"crypto/tls"
+	"encoding"
 	"fmt"
 	"io"
 	"net"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 	"unsafe"
@@ -106,10 +108,7 @@ func (app *App) methodExist(c *DefaultCtx) bool {
 		// Reset stack index
 		c.setIndexRoute(-1)
 
-		tree, ok := c.App().treeStack[i][c.getTreePath()]
-		if !ok {
-			tree = c.App().treeStack[i][""]
-		}
+		tree := c.getTreeStack()[i].find(c.getDetectionPath())
 		// Get stack length
 		lenr := len(tree) - 1
 		// Loop over the route stack starting from previous index
@@ -122,8 +121,9 @@ func (app *App) methodExist(c *DefaultCtx) bool {
 			if route.use {
 				continue
 			}
-			// Check if it matches the request path
-			match := route.match(c.getDetectionPath(), c.Path(), c.getValues())
+			// Check if it matches the request path and hostname
+			match := route.match(c.getDetectionPath(), c.Path(), c.getValues()) &&
+				route.matchDomain(c, c.getValues())
 			// No match, next route
 			if match {
 				// We matched
@@ -150,10 +150,7 @@ func (app *App) methodExistCustom(c CustomCtx) bool {
 		// Reset stack index
 		c.setIndexRoute(-1)
 
-		tree, ok := c.App().treeStack[i][c.getTreePath()]
-		if !ok {
-			tree = c.App().treeStack[i][""]
-		}
+		tree := c.getTreeStack()[i].find(c.getDetectionPath())
 		// Get stack length
 		lenr := len(tree) - 1
 		// Loop over the route stack starting from previous index
@@ -166,8 +163,9 @@ func (app *App) methodExistCustom(c CustomCtx) bool {
 			if route.use {
 				continue
 			}
-			// Check if it matches the request path
-			match := route.match(c.getDetectionPath(), c.Path(), c.getValues())
+			// Check if it matches the request path and hostname
+			match := route.match(c.getDetectionPath(), c.Path(), c.getValues()) &&
+				route.matchDomain(c, c.getValues())
 			// No match, next route
 			if match {
 				// We matched
@@ -182,6 +180,30 @@ func (app *App) methodExistCustom(c CustomCtx) bool {
 	return exists
 }
 
+// autoOptions answers an OPTIONS request without matching route, the methods
+// added to the Allow header by methodExist are completed by HEAD, if GET exists,
+// and by OPTIONS itself
+func (app *App) autoOptions(c Ctx) {
+	allowed := strings.Split(c.GetRespHeader(HeaderAllow), ", ")
+	isAllowed := func(method string) bool {
+		for _, m := range allowed {
+			if m == method {
+				return true
+			}
+		}
+		return false
+	}
+
+	methods := make([]string, 0, len(allowed)+2)
+	for _, method := range app.config.RequestMethods {
+		if method == MethodOptions || isAllowed(method) || (method == MethodHead && isAllowed(MethodGet)) {
+			methods = append(methods, method)
+		}
+	}
+	c.Set(HeaderAllow, strings.Join(methods, ", "))
+	c.Status(StatusNoContent)
+}
+
 // uniqueRouteStack drop all not unique routes from the slice
 func uniqueRouteStack(stack []*Route) []*Route {
 	var unique []*Route
@@ -206,6 +228,78 @@ func defaultString(value string, defaultValue []string) string {
 	return value
 }
 
+// parseValueOrDefault converts the value to V, it returns the default value,
+// or the zero value of V if none is given, if the value is empty, invalid or
+// V is not supported
+func parseValueOrDefault[V any](value string, defaultValue []V) V {
+	var v V
+	if len(value) == 0 || parseValue(value, &v) != nil {
+		if len(defaultValue) > 0 {
+			return defaultValue[0]
+		}
+		var zero V
+		return zero
+	}
+	return v
+}
+
+// parseValue converts the value into out, which has to point to a string, bool,
+// integer, unsigned integer, float, time.Duration or encoding.TextUnmarshaler,
+// like time.Time which is parsed as RFC 3339. Named types of these kinds are
+// supported too, an error is returned for all other types.
+func parseValue(value string, out any) error {
+	switch out := out.(type) {
+	case *string:
+		*out = value
+		return nil
+	case *time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("failed to convert: %w", err)
+		}
+		*out = d
+		return nil
+	case encoding.TextUnmarshaler:
+		if err := out.UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("failed to convert: %w", err)
+		}
+		return nil
+	}
+
+	rv := reflect.ValueOf(out).Elem()
+	switch rv.Kind() { //nolint:exhaustive // All other kinds are not supported
+	case reflect.String:
+		rv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("failed to convert: %w", err)
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("failed to convert: %w", err)
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("failed to convert: %w", err)
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("failed to convert: %w", err)
+		}
+		rv.SetFloat(f)
+	default:
+		return fmt.Errorf("failed to convert: unsupported type %s", rv.Type())
+	}
+	return nil
+}
+
 func getGroupPath(prefix, path string) string {
 	if len(path) == 0 {
 		return prefix
@@ -653,6 +747,11 @@ func matchEtag(s, etag string) bool {
 	return false
 }
 
+// matchEtagStrong reports whether both entity tags are strong and equal, see RFC 9110 section 8.8.3.2.
+func matchEtagStrong(s, etag string) bool {
+	return s == etag && !strings.HasPrefix(s, "W/")
+}
+
 func (app *App) isEtagStale(etag string, noneMatchBytes []byte) bool {
 	var start, end int
 
@@ -1059,6 +1158,7 @@ const (
 	HeaderReportTo                        = "Report-To"
 	HeaderTE                              = "TE"
 	HeaderTrailer                         = "Trailer"
+	HeaderTraceparent                     = "Traceparent"
 	HeaderTransferEncoding                = "Transfer-Encoding"
 	HeaderSecWebSocketAccept              = "Sec-WebSocket-Accept"
 	HeaderSecWebSocketExtensions          = "Sec-WebSocket-Extensions"
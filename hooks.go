@@ -6,14 +6,15 @@ import (
 
 // OnRouteHandler Handlers define a function to create hooks for Fiber.
 type (
-	OnRouteHandler     = func(Route) error
-	OnNameHandler      = OnRouteHandler
-	OnGroupHandler     = func(Group) error
-	OnGroupNameHandler = OnGroupHandler
-	OnListenHandler    = func(ListenData) error
-	OnShutdownHandler  = func() error
-	OnForkHandler      = func(int) error
-	OnMountHandler     = func(*App) error
+	OnRouteHandler       = func(Route) error
+	OnRouteRemoveHandler = OnRouteHandler
+	OnNameHandler        = OnRouteHandler
+	OnGroupHandler       = func(Group) error
+	OnGroupNameHandler   = OnGroupHandler
+	OnListenHandler      = func(ListenData) error
+	OnShutdownHandler    = func() error
+	OnForkHandler        = func(int) error
+	OnMountHandler       = func(*App) error
 )
 
 // Hooks is a struct to use it with App.
@@ -22,14 +23,15 @@ type Hooks struct {
 	app *App
 
 	// Hooks
-	onRoute     []OnRouteHandler
-	onName      []OnNameHandler
-	onGroup     []OnGroupHandler
-	onGroupName []OnGroupNameHandler
-	onListen    []OnListenHandler
-	onShutdown  []OnShutdownHandler
-	onFork      []OnForkHandler
-	onMount     []OnMountHandler
+	onRoute       []OnRouteHandler
+	onRouteRemove []OnRouteRemoveHandler
+	onName        []OnNameHandler
+	onGroup       []OnGroupHandler
+	onGroupName   []OnGroupNameHandler
+	onListen      []OnListenHandler
+	onShutdown    []OnShutdownHandler
+	onFork        []OnForkHandler
+	onMount       []OnMountHandler
 }
 
 // ListenData is a struct to use it with OnListenHandler
@@ -41,15 +43,16 @@ type ListenData struct {
 
 func newHooks(app *App) *Hooks {
 	return &Hooks{
-		app:         app,
-		onRoute:     make([]OnRouteHandler, 0),
-		onGroup:     make([]OnGroupHandler, 0),
-		onGroupName: make([]OnGroupNameHandler, 0),
-		onName:      make([]OnNameHandler, 0),
-		onListen:    make([]OnListenHandler, 0),
-		onShutdown:  make([]OnShutdownHandler, 0),
-		onFork:      make([]OnForkHandler, 0),
-		onMount:     make([]OnMountHandler, 0),
+		app:           app,
+		onRoute:       make([]OnRouteHandler, 0),
+		onRouteRemove: make([]OnRouteRemoveHandler, 0),
+		onGroup:       make([]OnGroupHandler, 0),
+		onGroupName:   make([]OnGroupNameHandler, 0),
+		onName:        make([]OnNameHandler, 0),
+		onListen:      make([]OnListenHandler, 0),
+		onShutdown:    make([]OnShutdownHandler, 0),
+		onFork:        make([]OnForkHandler, 0),
+		onMount:       make([]OnMountHandler, 0),
 	}
 }
 
@@ -61,6 +64,15 @@ func (h *Hooks) OnRoute(handler ...OnRouteHandler) {
 	h.app.mutex.Unlock()
 }
 
+// OnRouteRemove is a hook to execute user functions on each route removal
+// with RemoveRoute or RemoveRouteByName.
+// Also you can get route properties by route parameter.
+func (h *Hooks) OnRouteRemove(handler ...OnRouteRemoveHandler) {
+	h.app.mutex.Lock()
+	h.onRouteRemove = append(h.onRouteRemove, handler...)
+	h.app.mutex.Unlock()
+}
+
 // OnName is a hook to execute user functions on each route naming.
 // Also you can get route properties by route parameter.
 //
@@ -135,6 +147,16 @@ func (h *Hooks) executeOnRouteHooks(route Route) error {
 	return nil
 }
 
+func (h *Hooks) executeOnRouteRemoveHooks(route Route) error {
+	for _, v := range h.onRouteRemove {
+		if err := v(route); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (h *Hooks) executeOnNameHooks(route Route) error {
 	// Check mounting
 	if h.app.mountFields.mountPath != "" {
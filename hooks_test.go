@@ -32,6 +32,33 @@ func Test_Hook_OnRoute(t *testing.T) {
 	app.Use("/sub", subApp)
 }
 
+func Test_Hook_OnRouteRemove(t *testing.T) {
+	t.Parallel()
+	app := New()
+
+	var removed []string
+	app.Hooks().OnRouteRemove(func(r Route) error {
+		removed = append(removed, r.Method+" "+r.Path+" "+r.Name)
+
+		return nil
+	})
+
+	app.Get("/users", testSimpleHandler).Name("users")
+	app.Post("/users", testSimpleHandler)
+	app.Use("/users", testSimpleHandler)
+
+	app.RemoveRoute("/users")
+	require.Equal(t, []string{"GET /users users", "POST /users "}, removed)
+
+	app.Get("/fail", testSimpleHandler)
+	app.Hooks().OnRouteRemove(func(Route) error {
+		return errors.New("unknown error")
+	})
+	require.PanicsWithError(t, "unknown error", func() {
+		app.RemoveRoute("/fail")
+	})
+}
+
 func Test_Hook_OnRoute_Mount(t *testing.T) {
 	t.Parallel()
 	app := New()
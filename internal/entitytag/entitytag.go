@@ -0,0 +1,57 @@
+// Package entitytag implements the entity tag comparison of RFC 9110 section 8.8.3.2,
+// which is shared by the conditional request helpers of the context and the etag middleware
+package entitytag
+
+import "bytes"
+
+var weakPrefix = []byte("W/")
+
+// MatchList reports whether the comma-separated list of entity tags contains
+// "*" or a tag matching etag, using the weak or strong comparison function.
+// Commas inside quoted entity tags are part of the tag.
+func MatchList(list, etag []byte, weak bool) bool {
+	for len(list) > 0 {
+		// Skip leading whitespace and empty list elements
+		if list[0] == ' ' || list[0] == '\t' || list[0] == ',' {
+			list = list[1:]
+			continue
+		}
+
+		// Find the end of the current tag, commas inside quotes are part of it
+		end, quoted := 0, false
+		for ; end < len(list); end++ {
+			if list[end] == '"' {
+				quoted = !quoted
+			} else if list[end] == ',' && !quoted {
+				break
+			}
+		}
+		tag := bytes.TrimRight(list[:end], " \t")
+		list = list[end:]
+
+		if len(tag) == 1 && tag[0] == '*' {
+			return true
+		}
+		if MatchTag(tag, etag, weak) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchTag compares two entity tags using the weak or strong comparison function.
+func MatchTag(a, b []byte, weak bool) bool {
+	aWeak, bWeak := bytes.HasPrefix(a, weakPrefix), bytes.HasPrefix(b, weakPrefix)
+	if !weak && (aWeak || bWeak) {
+		return false
+	}
+	if aWeak {
+		a = a[len(weakPrefix):]
+	}
+	if bWeak {
+		b = b[len(weakPrefix):]
+	}
+
+	return len(a) > 0 && bytes.Equal(a, b)
+}
@@ -0,0 +1,35 @@
+package entitytag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// go test -run Test_MatchList
+func Test_MatchList(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		list  string
+		etag  string
+		weak  bool
+		match bool
+	}{
+		{list: `*`, etag: `"a"`, match: true},
+		{list: `"a", "b"`, etag: `"b"`, match: true},
+		{list: `"a",,  "b"`, etag: `"b"`, match: true},
+		{list: `"a,b"`, etag: `"a,b"`, match: true},
+		{list: `"a,b"`, etag: `"b"`},
+		{list: `"x", "a,b", "y"`, etag: `"a,b"`, match: true},
+		{list: `W/"a"`, etag: `"a"`},
+		{list: `W/"a"`, etag: `"a"`, weak: true, match: true},
+		{list: `"a"`, etag: `W/"a"`, weak: true, match: true},
+		{list: `"a"`, etag: ``},
+		{list: ``, etag: `"a"`},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.match, MatchList([]byte(tc.list), []byte(tc.etag), tc.weak), "%s %s %t", tc.list, tc.etag, tc.weak)
+	}
+}
@@ -0,0 +1,58 @@
+package log
+
+import (
+	"context"
+)
+
+// ContextExtractor extracts a key-value pair from a context.
+// It reports false if the context does not carry the value.
+type ContextExtractor func(ctx context.Context) (key string, value any, ok bool)
+
+// The contextKey type is unexported to prevent collisions with context keys defined in
+// other packages.
+type contextKey int
+
+// The keys for the values in context
+const (
+	fieldsKey contextKey = iota
+)
+
+var extractors []ContextExtractor
+
+// RegisterContextExtractor registers extractors whose key-value pairs are added to
+// every log entry of a logger bound to a context with WithContext.
+// Note that this method is not concurrent-safe and should be called during initialization.
+func RegisterContextExtractor(extractor ...ContextExtractor) {
+	extractors = append(extractors, extractor...)
+}
+
+// WithFields returns a copy of ctx carrying the key-value pairs, in addition to
+// the pairs already stored in ctx. They are added to every log entry of a logger
+// bound to the returned context with WithContext.
+func WithFields(ctx context.Context, keysAndValues ...any) context.Context {
+	parent, _ := ctx.Value(fieldsKey).([]any) //nolint:errcheck // nil is fine when no fields are set
+	fields := make([]any, 0, len(parent)+len(keysAndValues))
+	fields = append(fields, parent...)
+	fields = append(fields, keysAndValues...)
+	return context.WithValue(ctx, fieldsKey, fields)
+}
+
+// FieldsFromContext returns the key-value pairs of the registered extractors
+// followed by the pairs set with WithFields.
+// Custom loggers can use it to implement WithContext.
+func FieldsFromContext(ctx context.Context) []any {
+	if ctx == nil {
+		return nil
+	}
+
+	var fields []any
+	for _, extractor := range extractors {
+		if key, value, ok := extractor(ctx); ok {
+			fields = append(fields, key, value)
+		}
+	}
+	if userFields, ok := ctx.Value(fieldsKey).([]any); ok {
+		fields = append(fields, userFields...)
+	}
+	return fields
+}
@@ -15,6 +15,7 @@ var _ AllLogger = (*defaultLogger)(nil)
 
 type defaultLogger struct {
 	stdlog *log.Logger
+	fields []any
 	level  Level
 	depth  int
 }
@@ -29,6 +30,7 @@ func (l *defaultLogger) privateLog(lv Level, fmtArgs []any) {
 	buf := bytebufferpool.Get()
 	_, _ = buf.WriteString(level)                  //nolint:errcheck // It is fine to ignore the error
 	_, _ = buf.WriteString(fmt.Sprint(fmtArgs...)) //nolint:errcheck // It is fine to ignore the error
+	l.writeFields(buf, false)
 
 	_ = l.stdlog.Output(l.depth, buf.String()) //nolint:errcheck // It is fine to ignore the error
 	buf.Reset()
@@ -53,6 +55,7 @@ func (l *defaultLogger) privateLogf(lv Level, format string, fmtArgs []any) {
 	} else {
 		_, _ = fmt.Fprint(buf, fmtArgs...)
 	}
+	l.writeFields(buf, false)
 	_ = l.stdlog.Output(l.depth, buf.String()) //nolint:errcheck // It is fine to ignore the error
 	buf.Reset()
 	bytebufferpool.Put(buf)
@@ -94,6 +97,7 @@ func (l *defaultLogger) privateLogw(lv Level, format string, keysAndValues []any
 			_, _ = fmt.Fprintf(buf, " %s=%v", keysAndValues[i], keysAndValues[i+1])
 		}
 	}
+	l.writeFields(buf, format == "" && isFirst)
 
 	_ = l.stdlog.Output(l.depth, buf.String()) //nolint:errcheck // It is fine to ignore the error
 	buf.Reset()
@@ -103,6 +107,18 @@ func (l *defaultLogger) privateLogw(lv Level, format string, keysAndValues []any
 	}
 }
 
+// writeFields writes the fields of the bound context to the buffer
+func (l *defaultLogger) writeFields(buf *bytebufferpool.ByteBuffer, isFirst bool) {
+	for i := 0; i+1 < len(l.fields); i += 2 {
+		if isFirst {
+			_, _ = fmt.Fprintf(buf, "%s=%v", l.fields[i], l.fields[i+1])
+			isFirst = false
+			continue
+		}
+		_, _ = fmt.Fprintf(buf, " %s=%v", l.fields[i], l.fields[i+1])
+	}
+}
+
 func (l *defaultLogger) Trace(v ...any) {
 	l.privateLog(LevelTrace, v)
 }
@@ -187,9 +203,16 @@ func (l *defaultLogger) Panicw(msg string, keysAndValues ...any) {
 	l.privateLogw(LevelPanic, msg, keysAndValues)
 }
 
-func (l *defaultLogger) WithContext(_ context.Context) CommonLogger {
+// WithContext returns a logger which adds the fields of the context to every log entry,
+// see FieldsFromContext.
+func (l *defaultLogger) WithContext(ctx context.Context) CommonLogger {
+	fields := FieldsFromContext(ctx)
+	if (len(fields) & 1) == 1 {
+		fields = append(fields, "KEYVALS UNPAIRED")
+	}
 	return &defaultLogger{
 		stdlog: l.stdlog,
+		fields: append(append([]any(nil), l.fields...), fields...),
 		level:  l.level,
 		depth:  l.depth - 1,
 	}
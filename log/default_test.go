@@ -172,6 +172,41 @@ func Test_WithContextCaller(t *testing.T) {
 	require.Equal(t, "default_test.go:169: [Info] \ndefault_test.go:170: [Info] \n", string(w.b))
 }
 
+type testExtractorKey struct{}
+
+func Test_CtxLoggerFields(t *testing.T) {
+	initDefaultLogger()
+	defer func(registered []ContextExtractor) {
+		extractors = registered
+	}(extractors)
+	RegisterContextExtractor(func(ctx context.Context) (string, any, bool) {
+		v, ok := ctx.Value(testExtractorKey{}).(string)
+		return "extracted", v, ok
+	})
+
+	var w byteSliceWriter
+	SetOutput(&w)
+
+	ctx := WithFields(context.Background(), "user", "john")
+	ctx = WithFields(ctx, "tenant", 42)
+	ctx = context.WithValue(ctx, testExtractorKey{}, "value")
+
+	WithContext(ctx).Info("starting ", work)
+	WithContext(ctx).Infof("%s done", work)
+	WithContext(ctx).Infow("", "job", work)
+	WithContext(ctx).Infow(work)
+	WithContext(WithFields(context.Background(), "unpaired")).Info(work)
+
+	require.Equal(t, "[Info] starting work extracted=value user=john tenant=42\n"+
+		"[Info] work done extracted=value user=john tenant=42\n"+
+		"[Info] job=work extracted=value user=john tenant=42\n"+
+		"[Info] work extracted=value user=john tenant=42\n"+
+		"[Info] work unpaired=KEYVALS UNPAIRED\n", string(w.b))
+
+	require.Equal(t, []any{"extracted", "value", "user", "john", "tenant", 42}, FieldsFromContext(ctx))
+	require.Empty(t, FieldsFromContext(context.Background()))
+}
+
 func Test_SetLevel(t *testing.T) {
 	setLogger := &defaultLogger{
 		stdlog: log.New(os.Stderr, "", log.LstdFlags|log.Lshortfile|log.Lmicroseconds),
@@ -117,6 +117,9 @@ func Panicw(msg string, keysAndValues ...any) {
 	logger.Panicw(msg, keysAndValues...)
 }
 
+// WithContext returns the default logger bound to ctx, the fields of the
+// registered extractors and of WithFields are added to every log entry.
+// Use fiber.Ctx.LogContext to bind the logger to a request.
 func WithContext(ctx context.Context) CommonLogger {
 	return logger.WithContext(ctx)
 }
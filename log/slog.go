@@ -0,0 +1,204 @@
+//go:build go1.21
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"time"
+)
+
+var _ AllLogger = (*slogLogger)(nil)
+
+// slogLevels maps the levels to the levels of log/slog
+var slogLevels = [...]slog.Level{
+	LevelTrace: slog.LevelDebug - 4,
+	LevelDebug: slog.LevelDebug,
+	LevelInfo:  slog.LevelInfo,
+	LevelWarn:  slog.LevelWarn,
+	LevelError: slog.LevelError,
+	LevelFatal: slog.LevelError + 4,
+	LevelPanic: slog.LevelError + 8,
+}
+
+type slogLogger struct {
+	handler slog.Handler
+	ctx     context.Context //nolint:containedctx // The bound context is passed to the handler
+	level   Level
+	depth   int
+}
+
+// NewSlogLogger returns an AllLogger writing to the log/slog handler, so the
+// logs of Fiber can be routed into an existing slog pipeline with SetLogger.
+// The fields of a context bound with WithContext are added as attributes and
+// the context is passed to the handler.
+//
+//	log.SetLogger(log.NewSlogLogger(slog.NewJSONHandler(os.Stdout, nil)))
+func NewSlogLogger(handler slog.Handler) AllLogger {
+	return &slogLogger{
+		handler: handler,
+		ctx:     context.Background(),
+		depth:   5,
+	}
+}
+
+// privateLog creates a record for the message and the key-value pairs and passes it to the handler.
+// when the level is fatal, it will exit the program.
+func (l *slogLogger) privateLog(lv Level, msg func() string, keysAndValues []any) {
+	if l.level > lv {
+		return
+	}
+	level := slogLevels[lv]
+	if !l.handler.Enabled(l.ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(l.depth, pcs[:])
+	record := slog.NewRecord(time.Now(), level, msg(), pcs[0])
+	record.Add(keysAndValues...)
+	_ = l.handler.Handle(l.ctx, record) //nolint:errcheck // It is fine to ignore the error
+
+	if lv == LevelFatal {
+		os.Exit(1) //nolint:revive // we want to exit the program when Fatal is called
+	}
+}
+
+func (l *slogLogger) logv(lv Level, v []any) {
+	l.privateLog(lv, func() string { return fmt.Sprint(v...) }, nil)
+}
+
+func (l *slogLogger) logf(lv Level, format string, v []any) {
+	l.privateLog(lv, func() string { return fmt.Sprintf(format, v...) }, nil)
+}
+
+func (l *slogLogger) logw(lv Level, msg string, keysAndValues []any) {
+	l.privateLog(lv, func() string { return msg }, keysAndValues)
+}
+
+func (l *slogLogger) Trace(v ...any) {
+	l.logv(LevelTrace, v)
+}
+
+func (l *slogLogger) Debug(v ...any) {
+	l.logv(LevelDebug, v)
+}
+
+func (l *slogLogger) Info(v ...any) {
+	l.logv(LevelInfo, v)
+}
+
+func (l *slogLogger) Warn(v ...any) {
+	l.logv(LevelWarn, v)
+}
+
+func (l *slogLogger) Error(v ...any) {
+	l.logv(LevelError, v)
+}
+
+func (l *slogLogger) Fatal(v ...any) {
+	l.logv(LevelFatal, v)
+}
+
+func (l *slogLogger) Panic(v ...any) {
+	l.logv(LevelPanic, v)
+}
+
+func (l *slogLogger) Tracef(format string, v ...any) {
+	l.logf(LevelTrace, format, v)
+}
+
+func (l *slogLogger) Debugf(format string, v ...any) {
+	l.logf(LevelDebug, format, v)
+}
+
+func (l *slogLogger) Infof(format string, v ...any) {
+	l.logf(LevelInfo, format, v)
+}
+
+func (l *slogLogger) Warnf(format string, v ...any) {
+	l.logf(LevelWarn, format, v)
+}
+
+func (l *slogLogger) Errorf(format string, v ...any) {
+	l.logf(LevelError, format, v)
+}
+
+func (l *slogLogger) Fatalf(format string, v ...any) {
+	l.logf(LevelFatal, format, v)
+}
+
+func (l *slogLogger) Panicf(format string, v ...any) {
+	l.logf(LevelPanic, format, v)
+}
+
+func (l *slogLogger) Tracew(msg string, keysAndValues ...any) {
+	l.logw(LevelTrace, msg, keysAndValues)
+}
+
+func (l *slogLogger) Debugw(msg string, keysAndValues ...any) {
+	l.logw(LevelDebug, msg, keysAndValues)
+}
+
+func (l *slogLogger) Infow(msg string, keysAndValues ...any) {
+	l.logw(LevelInfo, msg, keysAndValues)
+}
+
+func (l *slogLogger) Warnw(msg string, keysAndValues ...any) {
+	l.logw(LevelWarn, msg, keysAndValues)
+}
+
+func (l *slogLogger) Errorw(msg string, keysAndValues ...any) {
+	l.logw(LevelError, msg, keysAndValues)
+}
+
+func (l *slogLogger) Fatalw(msg string, keysAndValues ...any) {
+	l.logw(LevelFatal, msg, keysAndValues)
+}
+
+func (l *slogLogger) Panicw(msg string, keysAndValues ...any) {
+	l.logw(LevelPanic, msg, keysAndValues)
+}
+
+// WithContext returns a logger which adds the fields of the context as attributes
+// and passes the context to the handler, see FieldsFromContext.
+func (l *slogLogger) WithContext(ctx context.Context) CommonLogger {
+	handler := l.handler
+	if fields := FieldsFromContext(ctx); len(fields) > 0 {
+		handler = handler.WithAttrs(argsToAttrs(fields))
+	}
+	return &slogLogger{
+		handler: handler,
+		ctx:     ctx,
+		level:   l.level,
+		depth:   l.depth - 1,
+	}
+}
+
+func (l *slogLogger) SetLevel(level Level) {
+	l.level = level
+}
+
+// SetOutput replaces the handler by a text handler writing to the writer.
+func (l *slogLogger) SetOutput(writer io.Writer) {
+	l.handler = slog.NewTextHandler(writer, &slog.HandlerOptions{
+		AddSource: true,
+		Level:     slogLevels[LevelTrace],
+	})
+}
+
+// argsToAttrs converts key-value pairs to attributes like slog.Logger.With does.
+func argsToAttrs(args []any) []slog.Attr {
+	record := slog.NewRecord(time.Time{}, 0, "", 0)
+	record.Add(args...)
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	return attrs
+}
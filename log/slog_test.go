@@ -0,0 +1,82 @@
+//go:build go1.21
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSlogLogger(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		AddSource: true,
+		Level:     slog.LevelDebug - 4,
+	}))
+
+	l.Trace("trace ", work)
+	l.Debugf("received %s order", work)
+	l.Infow("starting work", "job", 1)
+	l.Warn("work may fail")
+	l.Errorw("work failed", "unpaired")
+	l.WithContext(WithFields(context.Background(), "user", "john")).Info(work)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 6)
+
+	expected := []map[string]any{
+		{"level": "DEBUG-4", "msg": "trace work"},
+		{"level": "DEBUG", "msg": "received work order"},
+		{"level": "INFO", "msg": "starting work", "job": float64(1)},
+		{"level": "WARN", "msg": "work may fail"},
+		{"level": "ERROR", "msg": "work failed", "!BADKEY": "unpaired"},
+		{"level": "INFO", "msg": "work", "user": "john"},
+	}
+	for i, line := range lines {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		for k, v := range expected[i] {
+			require.Equal(t, v, entry[k], line)
+		}
+		require.Contains(t, entry, "source")
+	}
+}
+
+func Test_SlogLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSlogLogger(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	l.Trace("filtered by the handler")
+	l.SetLevel(LevelWarn)
+	l.Info("filtered by the logger")
+	l.Warn("logged")
+
+	require.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	require.Contains(t, buf.String(), "msg=logged")
+
+	var out bytes.Buffer
+	l.SetOutput(&out)
+	l.Error("new output")
+	require.Contains(t, out.String(), "msg=\"new output\"")
+}
+
+func Test_SlogLoggerGlobal(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(NewSlogLogger(slog.NewTextHandler(&buf, &slog.HandlerOptions{AddSource: true})))
+	defer initDefaultLogger()
+
+	Info("global")
+	WithContext(context.Background()).Info("bound")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		require.Contains(t, line, "slog_test.go")
+	}
+}
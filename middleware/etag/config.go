@@ -1,11 +1,35 @@
 package etag
 
 import (
+	"hash"
+
 	"github.com/gofiber/fiber/v3"
 )
 
 // Config defines the config for middleware.
 type Config struct {
+	// Next defines a function to skip this middleware when returned true.
+	//
+	// Optional. Default: nil
+	Next func(c fiber.Ctx) bool
+
+	// Hash returns a new hash.Hash used to generate the ETag from the
+	// response body, e.g. sha256.New or an xxhash constructor. The
+	// resulting tag is the hex encoded digest of the body.
+	//
+	// Optional. Default: nil (the ETag is "<length>-<crc32>")
+	Hash func() hash.Hash
+
+	// CurrentETag returns the entity tag of the currently selected
+	// representation of the target resource. It is used to evaluate
+	// If-Match for unsafe methods (POST, PUT, PATCH, DELETE, ...) before
+	// the handler is executed, so a failed precondition results in a
+	// 412 Precondition Failed without touching the resource. Returning an
+	// empty string means that the resource has no current representation.
+	//
+	// Optional. Default: nil (If-Match is not evaluated for unsafe methods)
+	CurrentETag func(c fiber.Ctx) (string, error)
+
 	// Weak indicates that a weak validator is used. Weak etags are easy
 	// to generate, but are far less useful for comparisons. Strong
 	// validators are ideal for comparisons but can be very difficult
@@ -15,17 +39,14 @@ type Config struct {
 	// when byte range requests are used, but strong etags mean range
 	// requests can still be cached.
 	Weak bool
-
-	// Next defines a function to skip this middleware when returned true.
-	//
-	// Optional. Default: nil
-	Next func(c fiber.Ctx) bool
 }
 
 // ConfigDefault is the default config
 var ConfigDefault = Config{
-	Weak: false,
-	Next: nil,
+	Next:        nil,
+	Hash:        nil,
+	CurrentETag: nil,
+	Weak:        false,
 }
 
 // Helper function to set default values
@@ -1,11 +1,21 @@
 package etag
 
 import (
-	"bytes"
+	"encoding/hex"
+	"hash"
 	"hash/crc32"
+	"strconv"
+	"sync"
 
 	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/internal/entitytag"
 	"github.com/valyala/bytebufferpool"
+	"github.com/valyala/fasthttp"
+)
+
+var (
+	normalizedHeaderETag = []byte("Etag")
+	weakPrefix           = []byte("W/")
 )
 
 // New creates a new middleware handler
@@ -13,14 +23,16 @@ func New(config ...Config) fiber.Handler {
 	// Set default config
 	cfg := configDefault(config...)
 
-	var (
-		normalizedHeaderETag = []byte("Etag")
-		weakPrefix           = []byte("W/")
-	)
-
 	const crcPol = 0xD5828281
 	crc32q := crc32.MakeTable(crcPol)
 
+	var hashPool sync.Pool
+	if cfg.Hash != nil {
+		hashPool.New = func() any {
+			return cfg.Hash()
+		}
+	}
+
 	// Return new handler
 	return func(c fiber.Ctx) error {
 		// Don't execute middleware if Next returns true
@@ -28,6 +40,21 @@ func New(config ...Config) fiber.Handler {
 			return c.Next()
 		}
 
+		safe := isSafeMethod(c.Method())
+
+		// Evaluate If-Match for unsafe methods before the handler runs
+		if !safe && cfg.CurrentETag != nil {
+			if ifMatch := c.Request().Header.Peek(fiber.HeaderIfMatch); len(ifMatch) > 0 {
+				current, err := cfg.CurrentETag(c)
+				if err != nil {
+					return err
+				}
+				if current == "" || !entitytag.MatchList(ifMatch, []byte(current), false) {
+					return c.SendStatus(fiber.StatusPreconditionFailed)
+				}
+			}
+		}
+
 		// Return err if next handler returns one
 		if err := c.Next(); err != nil {
 			return err
@@ -37,11 +64,6 @@ func New(config ...Config) fiber.Handler {
 		if c.Response().StatusCode() != fiber.StatusOK {
 			return nil
 		}
-		body := c.Response().Body()
-		// Skips ETag if no response body is present
-		if len(body) == 0 {
-			return nil
-		}
 		// Skip ETag if header is already present
 		if c.Response().Header.PeekBytes(normalizedHeaderETag) != nil {
 			return nil
@@ -57,44 +79,104 @@ func New(config ...Config) fiber.Handler {
 		}
 
 		_ = bb.WriteByte('"') //nolint:errcheck // This will never fail
-		bb.B = appendUint(bb.Bytes(), uint32(len(body)))
-		_ = bb.WriteByte('-') //nolint:errcheck // This will never fail
-		bb.B = appendUint(bb.Bytes(), crc32.Checksum(body, crc32q))
+		if c.Response().IsBodyStream() {
+			// Never read a streamed body, derive the tag from its metadata instead
+			if !appendMetadataTag(bb, c.Response()) {
+				return nil
+			}
+		} else if body := c.Response().Body(); len(body) > 0 {
+			if cfg.Hash != nil {
+				h := hashPool.Get().(hash.Hash) //nolint:forcetypeassert,errcheck // We store nothing else in the pool
+				h.Reset()
+				_, _ = h.Write(body) //nolint:errcheck // This will never fail
+				bb.B = appendHex(bb.Bytes(), h.Sum(nil))
+				hashPool.Put(h)
+			} else {
+				bb.B = appendUint(bb.Bytes(), uint32(len(body)))
+				_ = bb.WriteByte('-') //nolint:errcheck // This will never fail
+				bb.B = appendUint(bb.Bytes(), crc32.Checksum(body, crc32q))
+			}
+		} else if !appendMetadataTag(bb, c.Response()) {
+			// Skips ETag if no response body and no file metadata is present
+			return nil
+		}
 		_ = bb.WriteByte('"') //nolint:errcheck // This will never fail
 
 		etag := bb.Bytes()
+		c.Response().Header.SetCanonical(normalizedHeaderETag, etag)
 
-		// Get ETag header from request
-		clientEtag := c.Request().Header.Peek(fiber.HeaderIfNoneMatch)
+		// The tag describes the state after an unsafe method has been applied,
+		// so the request preconditions can only be evaluated for safe methods
+		if !safe {
+			return nil
+		}
 
-		// Check if client's ETag is weak
-		if bytes.HasPrefix(clientEtag, weakPrefix) {
-			// Check if server's ETag is weak
-			if bytes.Equal(clientEtag[2:], etag) || bytes.Equal(clientEtag[2:], etag[2:]) {
-				// W/1 == 1 || W/1 == W/1
+		// If-Match uses the strong comparison function
+		if ifMatch := c.Request().Header.Peek(fiber.HeaderIfMatch); len(ifMatch) > 0 {
+			if !entitytag.MatchList(ifMatch, etag, false) {
 				c.Context().ResetBody()
 
-				return c.SendStatus(fiber.StatusNotModified)
+				return c.SendStatus(fiber.StatusPreconditionFailed)
 			}
-			// W/1 != W/2 || W/1 != 2
-			c.Response().Header.SetCanonical(normalizedHeaderETag, etag)
-
 			return nil
 		}
 
-		if bytes.Contains(clientEtag, etag) {
-			// 1 == 1
-			c.Context().ResetBody()
+		// If-None-Match uses the weak comparison function
+		if noneMatch := c.Request().Header.Peek(fiber.HeaderIfNoneMatch); len(noneMatch) > 0 {
+			if entitytag.MatchList(noneMatch, etag, true) {
+				c.Context().ResetBody()
 
-			return c.SendStatus(fiber.StatusNotModified)
+				return c.SendStatus(fiber.StatusNotModified)
+			}
 		}
-		// 1 != 2
-		c.Response().Header.SetCanonical(normalizedHeaderETag, etag)
 
 		return nil
 	}
 }
 
+// isSafeMethod reports whether the method is safe as defined in RFC 9110 section 9.2.1.
+func isSafeMethod(method string) bool {
+	switch method {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions, fiber.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// appendMetadataTag appends an opaque tag built from the Content-Length and
+// Last-Modified headers of the response, as set by SendFile. It reports false
+// if the response does not carry this metadata.
+func appendMetadataTag(bb *bytebufferpool.ByteBuffer, resp *fasthttp.Response) bool {
+	lastModified := resp.Header.Peek(fiber.HeaderLastModified)
+	if len(lastModified) == 0 {
+		return false
+	}
+	modTime, err := fasthttp.ParseHTTPDate(lastModified)
+	if err != nil {
+		return false
+	}
+	size := resp.Header.ContentLength()
+	if size < 0 {
+		// Unknown or chunked length
+		size = 0
+	}
+
+	bb.B = strconv.AppendInt(bb.B, int64(size), 16)
+	_ = bb.WriteByte('-') //nolint:errcheck // This will never fail
+	bb.B = strconv.AppendInt(bb.B, modTime.Unix(), 16)
+
+	return true
+}
+
+// appendHex appends the hex encoding of src to dst and returns the extended dst.
+func appendHex(dst, src []byte) []byte {
+	n := len(dst)
+	dst = append(dst, make([]byte, hex.EncodedLen(len(src)))...)
+	hex.Encode(dst[n:], src)
+	return dst
+}
+
 // appendUint appends n to dst and returns the extended dst.
 func appendUint(dst []byte, n uint32) []byte {
 	var b [20]byte
@@ -2,8 +2,12 @@ package etag
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"io"
 	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/gofiber/fiber/v3"
@@ -262,6 +266,185 @@ func Test_ETag_CustomEtagPut(t *testing.T) {
 	require.Equal(t, fiber.StatusPreconditionFailed, resp.StatusCode)
 }
 
+// go test -run Test_ETag_CustomHash
+func Test_ETag_CustomHash(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+
+	app.Use(New(Config{Hash: sha256.New}))
+
+	app.Get("/", func(c fiber.Ctx) error {
+		return c.SendString("Hello, World!")
+	})
+
+	sum := sha256.Sum256([]byte("Hello, World!"))
+	expected := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.Equal(t, expected, resp.Header.Get(fiber.HeaderETag))
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	req.Header.Set(fiber.HeaderIfNoneMatch, expected)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+	require.Equal(t, expected, resp.Header.Get(fiber.HeaderETag))
+}
+
+// go test -run Test_ETag_IfNoneMatchList
+func Test_ETag_IfNoneMatchList(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+
+	app.Use(New())
+
+	app.Get("/", func(c fiber.Ctx) error {
+		return c.SendString("Hello, World!")
+	})
+
+	testCases := []struct {
+		header string
+		status int
+	}{
+		{header: `"foo", "13-1831710635"`, status: fiber.StatusNotModified},
+		{header: `"foo",W/"13-1831710635" , "bar"`, status: fiber.StatusNotModified},
+		{header: `*`, status: fiber.StatusNotModified},
+		{header: `"foo", "bar"`, status: fiber.StatusOK},
+		{header: `"a,b", "13-1831710635-x"`, status: fiber.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.Header.Set(fiber.HeaderIfNoneMatch, tc.header)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, tc.status, resp.StatusCode, tc.header)
+	}
+}
+
+// go test -run Test_ETag_IfMatch
+func Test_ETag_IfMatch(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+
+	app.Use(New())
+
+	app.Get("/", func(c fiber.Ctx) error {
+		return c.SendString("Hello, World!")
+	})
+
+	testCases := []struct {
+		header string
+		status int
+	}{
+		{header: `"13-1831710635"`, status: fiber.StatusOK},
+		{header: `"foo", "13-1831710635"`, status: fiber.StatusOK},
+		{header: `*`, status: fiber.StatusOK},
+		// If-Match uses the strong comparison function
+		{header: `W/"13-1831710635"`, status: fiber.StatusPreconditionFailed},
+		{header: `"foo"`, status: fiber.StatusPreconditionFailed},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.Header.Set(fiber.HeaderIfMatch, tc.header)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, tc.status, resp.StatusCode, tc.header)
+	}
+}
+
+// go test -run Test_ETag_IfMatchUnsafe
+func Test_ETag_IfMatchUnsafe(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+
+	current := `"v1"`
+	app.Use(New(Config{
+		CurrentETag: func(c fiber.Ctx) (string, error) {
+			if c.Path() == "/missing" {
+				return "", nil
+			}
+			if c.Path() == "/error" {
+				return "", fiber.ErrServiceUnavailable
+			}
+			return current, nil
+		},
+	}))
+
+	var calls int
+	app.Put("/*", func(c fiber.Ctx) error {
+		calls++
+		return c.SendString("updated")
+	})
+
+	testCases := []struct {
+		path   string
+		header string
+		status int
+	}{
+		{path: "/", header: `"v1"`, status: fiber.StatusOK},
+		{path: "/", header: `"v0", "v1"`, status: fiber.StatusOK},
+		{path: "/", header: `*`, status: fiber.StatusOK},
+		{path: "/", header: ``, status: fiber.StatusOK},
+		{path: "/", header: `"v0"`, status: fiber.StatusPreconditionFailed},
+		{path: "/", header: `W/"v1"`, status: fiber.StatusPreconditionFailed},
+		{path: "/missing", header: `*`, status: fiber.StatusPreconditionFailed},
+		{path: "/error", header: `"v1"`, status: fiber.StatusServiceUnavailable},
+	}
+
+	expectedCalls := 0
+	for _, tc := range testCases {
+		req := httptest.NewRequest(fiber.MethodPut, tc.path, nil)
+		if tc.header != "" {
+			req.Header.Set(fiber.HeaderIfMatch, tc.header)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, tc.status, resp.StatusCode, tc.header)
+		if tc.status == fiber.StatusOK {
+			expectedCalls++
+		}
+	}
+	require.Equal(t, expectedCalls, calls)
+}
+
+// go test -run Test_ETag_SendFile
+func Test_ETag_SendFile(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+
+	app.Use(New())
+
+	app.Get("/", func(c fiber.Ctx) error {
+		return c.SendFile("etag.go")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	info, err := os.Stat("etag.go")
+	require.NoError(t, err)
+	expected := fmt.Sprintf(`"%x-%x"`, info.Size(), info.ModTime().Unix())
+	require.Equal(t, expected, resp.Header.Get(fiber.HeaderETag))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, int(info.Size()), len(body))
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	req.Header.Set(fiber.HeaderIfNoneMatch, expected)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Empty(t, body)
+}
+
 // go test -v -run=^$ -bench=Benchmark_Etag -benchmem -count=4
 func Benchmark_Etag(b *testing.B) {
 	app := fiber.New()
@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+// asyncWriter writes log entries through a bounded queue to the underlying writer
+// in a separate goroutine, so the request is not blocked by slow outputs
+type asyncWriter struct {
+	out     io.Writer
+	queue   chan *bytebufferpool.ByteBuffer
+	done    chan struct{}
+	mu      sync.RWMutex
+	dropped atomic.Uint64
+	block   bool
+	closed  bool
+}
+
+// newAsyncWriter starts the goroutine writing the queued entries to out.
+// If block is false, entries are dropped when the queue is full.
+func newAsyncWriter(out io.Writer, queueSize int, block bool) *asyncWriter {
+	w := &asyncWriter{
+		out:   out,
+		queue: make(chan *bytebufferpool.ByteBuffer, queueSize),
+		done:  make(chan struct{}),
+		block: block,
+	}
+	go w.run()
+	return w
+}
+
+func (w *asyncWriter) run() {
+	defer close(w.done)
+	for buf := range w.queue {
+		if _, err := w.out.Write(buf.Bytes()); err != nil {
+			// There is something wrong with the given io.Writer
+			_, _ = fmt.Fprintf(os.Stderr, "Failed to write to log, %v\n", err)
+		}
+		bytebufferpool.Put(buf)
+	}
+}
+
+// Write queues a copy of p, it never returns a short write. After Close, p is
+// written directly to the underlying writer, e.g. for requests still in flight
+// during a graceful shutdown.
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return w.out.Write(p) //nolint:wrapcheck // The error of the underlying writer is passed on
+	}
+
+	buf := bytebufferpool.Get()
+	_, _ = buf.Write(p) //nolint:errcheck // This will never fail
+
+	if w.block {
+		w.queue <- buf
+		return len(p), nil
+	}
+	select {
+	case w.queue <- buf:
+	default:
+		bytebufferpool.Put(buf)
+		w.dropped.Add(1)
+	}
+	return len(p), nil
+}
+
+// Close writes all queued entries and stops the goroutine.
+func (w *asyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.queue)
+	w.mu.Unlock()
+
+	<-w.done
+	return nil
+}
+
+// Dropped returns the number of entries dropped because the queue was full.
+func (w *asyncWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/log"
 )
 
 // Config defines the config for middleware.
@@ -63,6 +64,56 @@ type Config struct {
 	// Optional. Default: defaultLogger
 	LoggerFunc func(c fiber.Ctx, data *Data, cfg Config) error
 
+	// KeyValueLogger receives the structured fields as key-value pairs instead of Output.
+	// Responses with status 5xx are logged with Errorw, 4xx with Warnw and all others with Infow.
+	// Only used when Fields is set.
+	//
+	// Optional. Default: nil
+	KeyValueLogger log.WithLogger
+
+	// Fields enables the structured output, each field is rendered as typed value
+	// of its tag. When set, Format is ignored.
+	//
+	// Optional. Default: nil
+	Fields []Field
+
+	// Encoding defines the encoding of the structured output, EncodingJSON or EncodingLogfmt.
+	//
+	// Optional. Default: EncodingJSON
+	Encoding string
+
+	// Redact lists the names of headers, query parameters, form values and cookies
+	// whose values are replaced by "[REDACTED]" in the structured output.
+	// The names are case-insensitive.
+	//
+	// Optional. Default: nil
+	Redact []string
+
+	// Sampler decides whether a request is logged, it is called after the request has been handled.
+	// Use NewSampler to create a sampler based on status codes and latency.
+	//
+	// Optional. Default: nil (all requests are logged)
+	Sampler func(c fiber.Ctx, data *Data) bool
+
+	// QueueSize enables asynchronous logging, the log entries are queued in a bounded queue
+	// of this size and written to Output by a separate goroutine. The queue is flushed and
+	// the goroutine is stopped when App is shut down, later entries are written synchronously.
+	//
+	// Optional. Default: 0 (synchronous)
+	QueueSize int
+
+	// App is the app the middleware is used with, the asynchronous writer is closed
+	// by its OnShutdown hook. Required when QueueSize is set.
+	//
+	// Optional. Default: nil
+	App *fiber.App
+
+	// BlockOnFullQueue defines if a request waits for free space in the queue when it is full,
+	// otherwise the log entry is dropped. Only used when QueueSize is set.
+	//
+	// Optional. Default: false
+	BlockOnFullQueue bool
+
 	// DisableColors defines if the logs output should be colorized
 	//
 	// Default: false
@@ -71,6 +122,7 @@ type Config struct {
 	enableColors     bool
 	enableLatency    bool
 	timeZoneLocation *time.Location
+	structuredFields []structuredField
 }
 
 const (
@@ -149,11 +201,19 @@ func configDefault(config ...Config) Config {
 	}
 
 	if cfg.LoggerFunc == nil {
-		cfg.LoggerFunc = ConfigDefault.LoggerFunc
+		if len(cfg.Fields) > 0 {
+			cfg.LoggerFunc = structuredLoggerInstance
+		} else {
+			cfg.LoggerFunc = ConfigDefault.LoggerFunc
+		}
+	}
+
+	if cfg.Encoding == "" {
+		cfg.Encoding = EncodingJSON
 	}
 
 	// Enable colors if no custom format or output is given
-	if !cfg.DisableColors && cfg.Output == ConfigDefault.Output {
+	if !cfg.DisableColors && len(cfg.Fields) == 0 && cfg.Output == ConfigDefault.Output {
 		cfg.enableColors = true
 	}
 
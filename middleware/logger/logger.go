@@ -24,15 +24,20 @@ func New(config ...Config) fiber.Handler {
 		cfg.timeZoneLocation = tz
 	}
 
-	// Check if format contains latency
-	cfg.enableLatency = strings.Contains(cfg.Format, "${"+TagLatency+"}")
+	// Check if format contains latency, the sampler may also depend on it
+	cfg.enableLatency = cfg.Sampler != nil || strings.Contains(cfg.Format, "${"+TagLatency+"}")
+	enableTime := strings.Contains(cfg.Format, "${"+TagTime+"}")
+	for _, f := range cfg.Fields {
+		cfg.enableLatency = cfg.enableLatency || f.Tag == TagLatency
+		enableTime = enableTime || f.Tag == TagTime
+	}
 
 	var timestamp atomic.Value
 	// Create correct timeformat
 	timestamp.Store(time.Now().In(cfg.timeZoneLocation).Format(cfg.TimeFormat))
 
 	// Update date/time every 500 milliseconds in a separate go routine
-	if strings.Contains(cfg.Format, "${"+TagTime+"}") {
+	if enableTime {
 		go func() {
 			for {
 				time.Sleep(cfg.TimeInterval)
@@ -59,13 +64,30 @@ func New(config ...Config) fiber.Handler {
 	// Before handling func
 	cfg.BeforeHandlerFunc(cfg)
 
+	// Write the log entries asynchronously through a bounded queue
+	if cfg.QueueSize > 0 {
+		if cfg.App == nil {
+			panic("logger: App is required when QueueSize is set")
+		}
+		asyncWriter := newAsyncWriter(cfg.Output, cfg.QueueSize, cfg.BlockOnFullQueue)
+		// write the queued log entries and stop the goroutine on shutdown
+		cfg.App.Hooks().OnShutdown(asyncWriter.Close)
+		cfg.Output = asyncWriter
+	}
+
 	// Logger data
 	// instead of analyzing the template inside(handler) each time, this is done once before
 	// and we create several slices of the same length with the functions to be executed and fixed parts.
-	templateChain, logFunChain, err := buildLogFuncChain(&cfg, createTagMap(&cfg))
+	tagFunctions := createTagMap(&cfg)
+	templateChain, logFunChain, err := buildLogFuncChain(&cfg, tagFunctions)
 	if err != nil {
 		panic(err)
 	}
+	if len(cfg.Fields) > 0 {
+		if cfg.structuredFields, err = buildStructuredFields(&cfg, tagFunctions); err != nil {
+			panic(err)
+		}
+	}
 
 	// Return new handler
 	return func(c fiber.Ctx) error {
@@ -122,6 +144,11 @@ func New(config ...Config) fiber.Handler {
 			data.Stop = time.Now()
 		}
 
+		// Skip requests which are not sampled
+		if cfg.Sampler != nil && !cfg.Sampler(c, data) {
+			return nil
+		}
+
 		// Logger instance & update some logger data fields
 		return cfg.LoggerFunc(c, data, cfg)
 	}
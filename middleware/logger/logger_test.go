@@ -4,6 +4,7 @@ package logger
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -647,3 +649,315 @@ func Test_Logger_EnableColors(t *testing.T) {
 
 	require.Equal(t, 1, int(*o))
 }
+
+// go test -run Test_Logger_Structured_JSON
+func Test_Logger_Structured_JSON(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+
+	app.Use(New(Config{
+		Fields: []Field{
+			{Tag: TagStatus},
+			{Tag: TagMethod, Key: "http.method"},
+			{Tag: TagRoute},
+			{Tag: TagLatency},
+			{Tag: TagReqHeader + "X-Request-ID", Key: "request_id"},
+			{Tag: TagReqHeader + "Authorization", Key: "auth"},
+			{Tag: TagQueryStringParams, Key: "query"},
+			{Tag: TagLocals + "user"},
+			{Tag: TagError},
+			{Tag: "custom_tag"},
+		},
+		CustomTags: map[string]LogFunc{
+			"custom_tag": func(output Buffer, c fiber.Ctx, data *Data, extraParam string) (int, error) {
+				return output.WriteString("quote \" and\nnewline")
+			},
+		},
+		Redact: []string{"authorization", "token"},
+		Output: buf,
+	}))
+	app.Get("/users/:id", func(c fiber.Ctx) error {
+		c.Locals("user", map[string]any{"name": "john", "admin": true})
+		return c.SendString("Hello fiber!")
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/users/1?token=secret&page=2", nil)
+	req.Header.Set("X-Request-ID", `abc"123`)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, float64(fiber.StatusOK), entry[TagStatus])
+	require.Equal(t, fiber.MethodGet, entry["http.method"])
+	require.Equal(t, "/users/:id", entry[TagRoute])
+	require.IsType(t, float64(0), entry[TagLatency])
+	require.Equal(t, `abc"123`, entry["request_id"])
+	require.Equal(t, "[REDACTED]", entry["auth"])
+	require.Equal(t, map[string]any{"token": "[REDACTED]", "page": "2"}, entry["query"])
+	require.Equal(t, map[string]any{"name": "john", "admin": true}, entry[TagLocals+"user"])
+	require.Nil(t, entry[TagError])
+	require.Equal(t, "quote \" and\nnewline", entry["custom_tag"])
+	require.True(t, bytes.HasSuffix(buf.Bytes(), []byte("}\n")))
+	require.NotContains(t, buf.String(), "secret")
+}
+
+// go test -run Test_Logger_Structured_RedactRepeatedHeader
+func Test_Logger_Structured_RedactRepeatedHeader(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+
+	app.Use(New(Config{
+		Fields: []Field{
+			{Tag: TagReqHeaders, Key: "headers"},
+		},
+		Redact: []string{"authorization", "cookie"},
+		Output: buf,
+	}))
+	app.Get("/", func(c fiber.Ctx) error {
+		return c.SendString("Hello fiber!")
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	req.Header.Add(fiber.HeaderAuthorization, "Bearer first-secret")
+	req.Header.Add(fiber.HeaderAuthorization, "Bearer second-secret")
+	req.Header.Add(fiber.HeaderCookie, "session=cookie-secret")
+	req.Header.Add(fiber.HeaderCookie, "other=cookie-secret")
+	req.Header.Add("X-Forwarded-For", "10.0.0.1")
+	req.Header.Add("X-Forwarded-For", "10.0.0.2")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var entry map[string]map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "[REDACTED]", entry["headers"][fiber.HeaderAuthorization])
+	require.Equal(t, "[REDACTED]", entry["headers"][fiber.HeaderCookie])
+	require.Equal(t, "10.0.0.1,10.0.0.2", entry["headers"]["X-Forwarded-For"])
+	require.NotContains(t, buf.String(), "secret")
+}
+
+// go test -run Test_Logger_Structured_Logfmt
+func Test_Logger_Structured_Logfmt(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+
+	app.Use(New(Config{
+		Fields: []Field{
+			{Tag: TagStatus},
+			{Tag: TagPath},
+			{Tag: TagError, Key: "err"},
+			{Tag: TagQuery + "q", Key: "search"},
+		},
+		Encoding: EncodingLogfmt,
+		Output:   buf,
+	}))
+	app.Get("/", func(c fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusTeapot, "short and stout")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/?q=a=b", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusTeapot, resp.StatusCode)
+	require.Equal(t, `status=418 path=/ err="short and stout" search="a=b"`+"\n", buf.String())
+}
+
+// go test -run Test_Logger_Structured_UnknownTag
+func Test_Logger_Structured_UnknownTag(t *testing.T) {
+	t.Parallel()
+	require.Panics(t, func() {
+		New(Config{
+			Fields: []Field{{Tag: "unknown"}},
+			Output: io.Discard,
+		})
+	})
+}
+
+type kvLogger struct {
+	level         string
+	msg           string
+	keysAndValues []any
+}
+
+func (l *kvLogger) log(level, msg string, keysAndValues []any) {
+	l.level, l.msg, l.keysAndValues = level, msg, keysAndValues
+}
+
+func (l *kvLogger) Tracew(msg string, keysAndValues ...any) { l.log("trace", msg, keysAndValues) }
+func (l *kvLogger) Debugw(msg string, keysAndValues ...any) { l.log("debug", msg, keysAndValues) }
+func (l *kvLogger) Infow(msg string, keysAndValues ...any)  { l.log("info", msg, keysAndValues) }
+func (l *kvLogger) Warnw(msg string, keysAndValues ...any)  { l.log("warn", msg, keysAndValues) }
+func (l *kvLogger) Errorw(msg string, keysAndValues ...any) { l.log("error", msg, keysAndValues) }
+func (l *kvLogger) Fatalw(msg string, keysAndValues ...any) { l.log("fatal", msg, keysAndValues) }
+func (l *kvLogger) Panicw(msg string, keysAndValues ...any) { l.log("panic", msg, keysAndValues) }
+
+// go test -run Test_Logger_Structured_KeyValueLogger
+func Test_Logger_Structured_KeyValueLogger(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+
+	kv := &kvLogger{}
+	app.Use(New(Config{
+		Fields: []Field{
+			{Tag: TagStatus},
+			{Tag: TagLatency},
+			{Tag: TagRespHeader + "Set-Cookie", Key: "cookie"},
+		},
+		Redact:         []string{"set-cookie"},
+		KeyValueLogger: kv,
+	}))
+	app.Get("/", func(c fiber.Ctx) error {
+		c.Cookie(&fiber.Cookie{Name: "session", Value: "secret"})
+		return fiber.ErrBadGateway
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadGateway, resp.StatusCode)
+	require.Equal(t, "error", kv.level)
+	require.Equal(t, "http request", kv.msg)
+	require.Len(t, kv.keysAndValues, 6)
+	require.Equal(t, []any{TagStatus, fiber.StatusBadGateway}, kv.keysAndValues[:2])
+	require.Equal(t, TagLatency, kv.keysAndValues[2])
+	require.IsType(t, time.Duration(0), kv.keysAndValues[3])
+	require.Equal(t, []any{"cookie", "[REDACTED]"}, kv.keysAndValues[4:])
+}
+
+// go test -run Test_Logger_AppendJSONString
+func Test_Logger_AppendJSONString(t *testing.T) {
+	t.Parallel()
+	for _, s := range []string{"", "plain", "quote \" backslash \\", "\x00\x1f\t\r\n", "unicode ✓ \u2028\u2029", "invalid \xff utf8"} {
+		var out string
+		require.NoError(t, json.Unmarshal(appendJSONString(nil, s), &out))
+		require.Equal(t, strings.ToValidUTF8(s, "�"), out)
+	}
+}
+
+// go test -run Test_Logger_Sampler
+func Test_Logger_Sampler(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+
+	app.Use(New(Config{
+		Format: "${status} ${path}\n",
+		Output: buf,
+		Sampler: NewSampler(SamplerConfig{
+			Rules: []SamplingRule{
+				{MinStatus: 200, MaxStatus: 299, Rate: 0},
+				{MinStatus: 400, MaxStatus: 499, Rate: 1},
+			},
+			SlowThreshold: 50 * time.Millisecond,
+		}),
+	}))
+	app.Get("/ok", func(c fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/slow", func(c fiber.Ctx) error {
+		time.Sleep(60 * time.Millisecond)
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/error", func(c fiber.Ctx) error {
+		return fiber.ErrInternalServerError
+	})
+
+	for _, path := range []string{"/ok", "/slow", "/missing", "/error"} {
+		_, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+		require.NoError(t, err)
+	}
+	require.Equal(t, "200 /slow\n404 /missing\n500 /error\n", buf.String())
+}
+
+// go test -run Test_Logger_Async
+func Test_Logger_Async(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+
+	var (
+		mu  sync.Mutex
+		out bytes.Buffer
+	)
+	app.Use(New(Config{
+		Format:    "${path}\n",
+		Output:    writerFunc(func(p []byte) (int, error) { mu.Lock(); defer mu.Unlock(); return out.Write(p) }),
+		QueueSize: 16,
+		App:       app,
+	}))
+	app.Get("/*", func(c fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	for _, path := range []string{"/a", "/b", "/c"} {
+		_, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+		require.NoError(t, err)
+	}
+
+	// The queue is flushed and the writer is closed by the shutdown hook
+	require.NoError(t, app.Shutdown())
+	mu.Lock()
+	require.Equal(t, "/a\n/b\n/c\n", out.String())
+	mu.Unlock()
+
+	// Requests still in flight are logged synchronously
+	_, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/d", nil))
+	require.NoError(t, err)
+	mu.Lock()
+	require.Equal(t, "/a\n/b\n/c\n/d\n", out.String())
+	mu.Unlock()
+
+	require.PanicsWithValue(t, "logger: App is required when QueueSize is set", func() {
+		New(Config{QueueSize: 16})
+	})
+}
+
+// go test -run Test_Logger_AsyncWriter_Drop
+func Test_Logger_AsyncWriter_Drop(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	var out bytes.Buffer
+	w := newAsyncWriter(writerFunc(func(p []byte) (int, error) {
+		<-release
+		return out.Write(p)
+	}), 1, false)
+
+	// The first entry is taken by the goroutine, the second one waits in the queue
+	for _, entry := range []string{"1", "2", "3", "4"} {
+		n, err := w.Write([]byte(entry))
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		if entry == "1" {
+			require.Eventually(t, func() bool { return len(w.queue) == 0 }, time.Second, time.Millisecond)
+		}
+	}
+	require.Equal(t, uint64(2), w.Dropped())
+
+	close(release)
+	require.NoError(t, w.Close())
+	require.Equal(t, "12", out.String())
+
+	// The goroutine is stopped, entries are written directly
+	<-w.done
+	_, err := w.Write([]byte("5"))
+	require.NoError(t, err)
+	require.Equal(t, "125", out.String())
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
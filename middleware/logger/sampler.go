@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// SamplingRule defines the rate at which responses with a status in [MinStatus, MaxStatus] are logged.
+type SamplingRule struct {
+	// MinStatus is the lowest status code of the rule, inclusive
+	MinStatus int
+
+	// MaxStatus is the highest status code of the rule, inclusive
+	MaxStatus int
+
+	// Rate is the fraction of the matching requests which are logged, between 0 and 1
+	Rate float64
+}
+
+// SamplerConfig defines the config of NewSampler.
+type SamplerConfig struct {
+	// Rules are evaluated in order, the first rule matching the response status
+	// determines the sampling rate. Requests without a matching rule are always logged.
+	//
+	// Optional. Default: nil
+	Rules []SamplingRule
+
+	// SlowThreshold defines the latency from which requests are always logged, regardless of the rules.
+	//
+	// Optional. Default: 0 (disabled)
+	SlowThreshold time.Duration
+}
+
+// NewSampler returns a Sampler that logs requests according to the status code based rules.
+//
+//	// log all 5xx, 1% of 2xx and all requests slower than one second
+//	logger.NewSampler(logger.SamplerConfig{
+//		Rules: []logger.SamplingRule{
+//			{MinStatus: 200, MaxStatus: 299, Rate: 0.01},
+//		},
+//		SlowThreshold: time.Second,
+//	})
+func NewSampler(config SamplerConfig) func(c fiber.Ctx, data *Data) bool {
+	return func(c fiber.Ctx, data *Data) bool {
+		if config.SlowThreshold > 0 && data.Stop.Sub(data.Start) >= config.SlowThreshold {
+			return true
+		}
+
+		status := c.Response().StatusCode()
+		for _, rule := range config.Rules {
+			if status < rule.MinStatus || status > rule.MaxStatus {
+				continue
+			}
+			switch {
+			case rule.Rate >= 1:
+				return true
+			case rule.Rate <= 0:
+				return false
+			default:
+				return rand.Float64() < rule.Rate //nolint:gosec // Sampling does not need a cryptographically secure random number
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,416 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/utils/v2"
+	"github.com/valyala/bytebufferpool"
+)
+
+// Encodings of the structured output
+const (
+	EncodingJSON   = "json"
+	EncodingLogfmt = "logfmt"
+)
+
+// redactedValue replaces the values of redacted headers, query parameters, form values and cookies
+const redactedValue = "[REDACTED]"
+
+// Field defines a single field of the structured output.
+type Field struct {
+	// Tag is the logger tag of the field, e.g. TagStatus or TagReqHeader + "X-Request-ID"
+	Tag string
+
+	// Key is the name of the field in the output.
+	//
+	// Optional. Default: Tag
+	Key string
+}
+
+// fieldValueFunc returns the typed value of a tag for the structured output
+type fieldValueFunc func(c fiber.Ctx, data *Data, param string) any
+
+// structuredField is a Field resolved at startup
+type structuredField struct {
+	key       string
+	param     string
+	valueFunc fieldValueFunc
+}
+
+// createFieldValueMap returns the typed value functions of the default tags
+func createFieldValueMap(cfg *Config) map[string]fieldValueFunc {
+	redacted := func(name, value string) any {
+		if isRedacted(cfg, name) {
+			return redactedValue
+		}
+		return value
+	}
+
+	return map[string]fieldValueFunc{
+		TagStatus: func(c fiber.Ctx, _ *Data, _ string) any {
+			return c.Response().StatusCode()
+		},
+		TagMethod: func(c fiber.Ctx, _ *Data, _ string) any {
+			return c.Method()
+		},
+		TagLatency: func(_ fiber.Ctx, data *Data, _ string) any {
+			return data.Stop.Sub(data.Start)
+		},
+		TagBytesReceived: func(c fiber.Ctx, _ *Data, _ string) any {
+			return len(c.Request().Body())
+		},
+		TagBytesSent: func(c fiber.Ctx, _ *Data, _ string) any {
+			if c.Response().Header.ContentLength() < 0 {
+				return 0
+			}
+			return len(c.Response().Body())
+		},
+		TagError: func(_ fiber.Ctx, data *Data, _ string) any {
+			if data.ChainErr != nil {
+				return data.ChainErr.Error()
+			}
+			return nil
+		},
+		TagReqHeader: func(c fiber.Ctx, _ *Data, param string) any {
+			return redacted(param, c.Get(param))
+		},
+		TagRespHeader: func(c fiber.Ctx, _ *Data, param string) any {
+			return redacted(param, c.GetRespHeader(param))
+		},
+		TagQuery: func(c fiber.Ctx, _ *Data, param string) any {
+			return redacted(param, c.Query(param))
+		},
+		TagForm: func(c fiber.Ctx, _ *Data, param string) any {
+			return redacted(param, c.FormValue(param))
+		},
+		TagCookie: func(c fiber.Ctx, _ *Data, param string) any {
+			return redacted(param, c.Cookies(param))
+		},
+		TagLocals: func(c fiber.Ctx, _ *Data, param string) any {
+			return c.Locals(param)
+		},
+		TagReqHeaders: func(c fiber.Ctx, _ *Data, _ string) any {
+			headers := make(map[string]string)
+			c.Request().Header.VisitAll(func(key, value []byte) {
+				k := string(key)
+				if prev, ok := headers[k]; ok {
+					// the first value of a redacted header is already replaced
+					if !isRedacted(cfg, k) {
+						headers[k] = prev + "," + string(value)
+					}
+					return
+				}
+				headers[k] = redacted(k, string(value)).(string) //nolint:forcetypeassert // redacted always returns a string
+			})
+			return headers
+		},
+		TagQueryStringParams: func(c fiber.Ctx, _ *Data, _ string) any {
+			params := make(map[string]string)
+			c.Request().URI().QueryArgs().VisitAll(func(key, value []byte) {
+				k := string(key)
+				params[k] = redacted(k, string(value)).(string) //nolint:forcetypeassert // redacted always returns a string
+			})
+			return params
+		},
+		TagRoute: func(c fiber.Ctx, _ *Data, _ string) any {
+			return c.Route().Path
+		},
+	}
+}
+
+// isRedacted reports whether the value of the named header, query parameter,
+// form value or cookie must not be logged
+func isRedacted(cfg *Config, name string) bool {
+	for _, r := range cfg.Redact {
+		if utils.EqualFold(r, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildStructuredFields resolves the configured fields to their value functions.
+// Tags without a typed value, including the custom tags, are rendered with their LogFunc.
+func buildStructuredFields(cfg *Config, tagFunctions map[string]LogFunc) ([]structuredField, error) {
+	valueFunctions := createFieldValueMap(cfg)
+	fields := make([]structuredField, 0, len(cfg.Fields))
+
+	for _, f := range cfg.Fields {
+		tag, param := f.Tag, ""
+		if index := strings.Index(f.Tag, paramSeparator); index != -1 {
+			tag, param = f.Tag[:index+1], f.Tag[index+1:]
+		}
+
+		field := structuredField{key: f.Key, param: param}
+		if field.key == "" {
+			field.key = f.Tag
+		}
+
+		if _, custom := cfg.CustomTags[tag]; !custom && valueFunctions[tag] != nil {
+			field.valueFunc = valueFunctions[tag]
+		} else if logFunc, ok := tagFunctions[tag]; ok {
+			field.valueFunc = func(c fiber.Ctx, data *Data, param string) any {
+				buf := bytebufferpool.Get()
+				defer bytebufferpool.Put(buf)
+				if _, err := logFunc(buf, c, data, param); err != nil {
+					return err.Error()
+				}
+				return buf.String()
+			}
+		} else {
+			return nil, errors.New("unknown tag \"" + f.Tag + "\" in logger fields")
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// structuredLoggerInstance writes the configured fields as JSON or logfmt to Output,
+// or passes them as key-value pairs to the KeyValueLogger
+func structuredLoggerInstance(c fiber.Ctx, data *Data, cfg Config) error {
+	if cfg.KeyValueLogger != nil {
+		keysAndValues := make([]any, 0, 2*len(cfg.structuredFields))
+		for _, f := range cfg.structuredFields {
+			keysAndValues = append(keysAndValues, f.key, f.valueFunc(c, data, f.param))
+		}
+
+		const msg = "http request"
+		switch status := c.Response().StatusCode(); {
+		case status >= fiber.StatusInternalServerError:
+			cfg.KeyValueLogger.Errorw(msg, keysAndValues...)
+		case status >= fiber.StatusBadRequest:
+			cfg.KeyValueLogger.Warnw(msg, keysAndValues...)
+		default:
+			cfg.KeyValueLogger.Infow(msg, keysAndValues...)
+		}
+
+		if cfg.Done != nil {
+			cfg.Done(c, nil)
+		}
+		return nil
+	}
+
+	// Get new buffer
+	buf := bytebufferpool.Get()
+
+	if cfg.Encoding == EncodingLogfmt {
+		for i, f := range cfg.structuredFields {
+			if i > 0 {
+				_ = buf.WriteByte(' ') //nolint:errcheck // This will never fail
+			}
+			buf.B = appendLogfmtKey(buf.B, f.key)
+			_ = buf.WriteByte('=') //nolint:errcheck // This will never fail
+			buf.B = appendLogfmtValue(buf.B, c, f.valueFunc(c, data, f.param))
+		}
+	} else {
+		_ = buf.WriteByte('{') //nolint:errcheck // This will never fail
+		for i, f := range cfg.structuredFields {
+			if i > 0 {
+				_ = buf.WriteByte(',') //nolint:errcheck // This will never fail
+			}
+			buf.B = appendJSONString(buf.B, f.key)
+			_ = buf.WriteByte(':') //nolint:errcheck // This will never fail
+			buf.B = appendJSONValue(buf.B, c, f.valueFunc(c, data, f.param))
+		}
+		_ = buf.WriteByte('}') //nolint:errcheck // This will never fail
+	}
+	_ = buf.WriteByte('\n') //nolint:errcheck // This will never fail
+
+	mu.Lock()
+	// Write buffer to output
+	if _, err := cfg.Output.Write(buf.Bytes()); err != nil {
+		// There is something wrong with the given io.Writer
+		_, _ = fmt.Fprintf(os.Stderr, "Failed to write to log, %v\n", err)
+	}
+	mu.Unlock()
+
+	if cfg.Done != nil {
+		cfg.Done(c, buf.Bytes())
+	}
+
+	// Put buffer back to pool
+	bytebufferpool.Put(buf)
+
+	return nil
+}
+
+// appendJSONValue appends v as JSON value to dst
+func appendJSONValue(dst []byte, c fiber.Ctx, v any) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(dst, "null"...)
+	case string:
+		return appendJSONString(dst, v)
+	case []byte:
+		return appendJSONString(dst, utils.UnsafeString(v))
+	case bool:
+		return strconv.AppendBool(dst, v)
+	case int:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(dst, v, 10)
+	case uint64:
+		return strconv.AppendUint(dst, v, 10)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return appendJSONString(dst, strconv.FormatFloat(v, 'g', -1, 64))
+		}
+		return strconv.AppendFloat(dst, v, 'g', -1, 64)
+	case time.Duration:
+		// Same representation as the JSON handler of log/slog
+		return strconv.AppendInt(dst, int64(v), 10)
+	case time.Time:
+		return appendJSONString(dst, v.Format(time.RFC3339Nano))
+	case error:
+		return appendJSONString(dst, v.Error())
+	case map[string]string:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		dst = append(dst, '{')
+		for i, k := range keys {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, k)
+			dst = append(dst, ':')
+			dst = appendJSONString(dst, v[k])
+		}
+		return append(dst, '}')
+	default:
+		raw, err := c.App().Config().JSONEncoder(v)
+		if err != nil {
+			return appendJSONString(dst, fmt.Sprintf("%v", v))
+		}
+		return append(dst, raw...)
+	}
+}
+
+// appendLogfmtValue appends v as logfmt value to dst
+func appendLogfmtValue(dst []byte, c fiber.Ctx, v any) []byte {
+	var s string
+	switch v := v.(type) {
+	case nil:
+		return dst
+	case string:
+		s = v
+	case []byte:
+		s = utils.UnsafeString(v)
+	case bool:
+		return strconv.AppendBool(dst, v)
+	case int:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(dst, v, 10)
+	case uint64:
+		return strconv.AppendUint(dst, v, 10)
+	case float64:
+		return strconv.AppendFloat(dst, v, 'g', -1, 64)
+	case time.Duration:
+		s = v.String()
+	case time.Time:
+		s = v.Format(time.RFC3339Nano)
+	case error:
+		s = v.Error()
+	case map[string]string:
+		s = utils.UnsafeString(appendJSONValue(nil, c, v))
+	default:
+		s = fmt.Sprintf("%v", v)
+	}
+
+	if needsLogfmtQuoting(s) {
+		return appendJSONString(dst, s)
+	}
+	return append(dst, s...)
+}
+
+// appendLogfmtKey appends a logfmt key to dst, replacing characters that are not allowed in keys
+func appendLogfmtKey(dst []byte, key string) []byte {
+	for i := 0; i < len(key); i++ {
+		if b := key[i]; b <= ' ' || b == '=' || b == '"' || b == 0x7f {
+			dst = append(dst, '_')
+		} else {
+			dst = append(dst, b)
+		}
+	}
+	return dst
+}
+
+// needsLogfmtQuoting reports whether a logfmt value must be quoted
+func needsLogfmtQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for i := 0; i < len(s); i++ {
+		if b := s[i]; b <= ' ' || b == '=' || b == '"' || b == '\\' || b >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s as quoted and escaped JSON string to dst,
+// invalid UTF-8 is replaced by the Unicode replacement character
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= ' ' && b != '"' && b != '\\' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				dst = append(dst, '\\', b)
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 and U+2029 are valid JSON but break JavaScript parsers
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[r&0xf])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
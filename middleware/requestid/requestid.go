@@ -2,6 +2,8 @@ package requestid
 
 import (
 	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/log"
+	"github.com/gofiber/utils/v2"
 )
 
 // The contextKey type is unexported to prevent collisions with context keys defined in
@@ -36,6 +38,10 @@ func New(config ...Config) fiber.Handler {
 		// Add the request ID to locals
 		c.Locals(requestIDKey, rid)
 
+		// Add the request ID to the log fields of the user context, see Ctx.LogContext
+		// the context may be used after the handler returned, so the ID is copied
+		c.SetUserContext(log.WithFields(c.UserContext(), "request_id", utils.CopyString(rid)))
+
 		// Continue stack
 		return c.Next()
 	}
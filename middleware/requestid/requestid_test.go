@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/log"
 	"github.com/stretchr/testify/require"
 )
 
@@ -74,3 +75,26 @@ func Test_RequestID_FromContext(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, reqID, ctxVal)
 }
+
+// go test -run Test_RequestID_LogContext
+func Test_RequestID_LogContext(t *testing.T) {
+	t.Parallel()
+	reqID := "ThisIsARequestId"
+
+	app := fiber.New()
+	app.Use(New(Config{
+		Generator: func() string {
+			return reqID
+		},
+	}))
+
+	var fields []any
+	app.Get("/", func(c fiber.Ctx) error {
+		fields = log.FieldsFromContext(c.LogContext())
+		return nil
+	})
+
+	_, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	require.NoError(t, err)
+	require.Equal(t, []any{"request_id", reqID, "route", "/"}, fields)
+}
@@ -83,7 +83,7 @@ func (grp *Group) mount(prefix string, subApp *App) Router {
 	}
 
 	// register mounted group
-	mountGroup := &Group{Prefix: groupPath, app: subApp}
+	mountGroup := &Group{Prefix: groupPath, app: subApp, domain: grp.domain, conditions: grp.conditions}
 	grp.app.register([]string{methodUse}, groupPath, mountGroup, nil)
 
 	// Execute onMount hooks
@@ -200,8 +200,15 @@ func (app *App) processSubAppsRoutes() {
 				// Clone the sub-app's route
 				subAppRouteClone := app.copyRoute(subAppRoute)
 
-				// Add the parent route's path as a prefix to the sub-app's route
-				app.addPrefixToRoute(route.path, subAppRouteClone)
+				// Add the parent route's path as a prefix to the sub-app's route,
+				// the constraints of the sub-app are kept for its own patterns
+				app.addPrefixToRoute(route.path, subAppRouteClone, route.group.app.config.CustomConstraints...)
+
+				// Inherit the domain and the conditions of the mount point
+				subAppRouteClone.conditions = joinConditions(route.conditions, subAppRouteClone.conditions)
+				if subAppRouteClone.domain == nil {
+					subAppRouteClone.setDomain(route.domain)
+				}
 
 				// Add the cloned sub-app's route to the slice of sub-app routes
 				subRoutes[j] = subAppRouteClone
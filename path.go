@@ -7,6 +7,7 @@
 package fiber
 
 import (
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -65,9 +66,25 @@ const (
 type TypeConstraint int16
 
 type Constraint struct {
-	ID            TypeConstraint
-	RegexCompiler *regexp.Regexp
-	Data          []string
+	ID               TypeConstraint
+	RegexCompiler    *regexp.Regexp
+	CustomConstraint CustomConstraint
+	Data             []string
+}
+
+// CustomConstraint is a user-defined route parameter constraint, it is used in
+// route patterns by its name like the built-in ones, e.g. ":id<ulid>" or ":id<prefix(usr_)>".
+type CustomConstraint interface {
+	// Name returns the name of the constraint in route patterns, it is case-insensitive
+	Name() string
+	// Execute checks if the parameter value satisfies the constraint with the given arguments
+	Execute(param string, args ...string) bool
+}
+
+// CustomConstraintValidator can optionally be implemented by a CustomConstraint
+// to validate the arguments of the constraint when a route is registered.
+type CustomConstraintValidator interface {
+	ValidateArgs(args []string) error
 }
 
 const (
@@ -86,6 +103,7 @@ const (
 	maxConstraint
 	rangeConstraint
 	regexConstraint
+	customConstraint
 )
 
 // list of possible parameter and segment delimiter
@@ -149,7 +167,7 @@ func RoutePatternMatch(path, pattern string, cfg ...Config) bool {
 		patternPretty = strings.TrimRight(patternPretty, "/")
 	}
 
-	parser := parseRoute(patternPretty)
+	parser := parsePrettyRoute(pattern, config.CaseSensitive, config.StrictRouting, config.CustomConstraints...)
 
 	if patternPretty == "/" && path == "/" {
 		return true
@@ -173,9 +191,24 @@ func RoutePatternMatch(path, pattern string, cfg ...Config) bool {
 	return false
 }
 
+// parsePrettyRoute parses the pattern of a route like it is matched. Without
+// strict routing trailing slashes are removed, and without case sensitivity
+// only the constant parts are lowercased, the names and arguments of the
+// constraints keep their case.
+func parsePrettyRoute(pattern string, caseSensitive, strictRouting bool, customConstraints ...CustomConstraint) routeParser {
+	if !strictRouting && len(pattern) > 1 {
+		pattern = strings.TrimRight(pattern, "/")
+	}
+	parser := parseRoute(pattern, customConstraints...)
+	if !caseSensitive {
+		return parser.foldCase()
+	}
+	return parser
+}
+
 // parseRoute analyzes the route and divides it into segments for constant areas and parameters,
 // this information is needed later when assigning the requests to the declared routes
-func parseRoute(pattern string) routeParser {
+func parseRoute(pattern string, customConstraints ...CustomConstraint) routeParser {
 	parser := routeParser{}
 
 	part := ""
@@ -183,7 +216,7 @@ func parseRoute(pattern string) routeParser {
 		nextParamPosition := findNextParamPosition(pattern)
 		// handle the parameter part
 		if nextParamPosition == 0 {
-			processedPart, seg := parser.analyseParameterPart(pattern)
+			processedPart, seg := parser.analyseParameterPart(pattern, customConstraints)
 			parser.params, parser.segs, part = append(parser.params, seg.ParamName), append(parser.segs, seg), processedPart
 		} else {
 			processedPart, seg := parser.analyseConstantPart(pattern, nextParamPosition)
@@ -205,6 +238,39 @@ func parseRoute(pattern string) routeParser {
 	return parser
 }
 
+// foldCase returns a copy of the parser with lowercased constant parts, to match
+// lowercased paths with it
+func (routeParser *routeParser) foldCase() routeParser {
+	folded := *routeParser
+	folded.segs = make([]*routeSegment, len(routeParser.segs))
+	for i, seg := range routeParser.segs {
+		foldedSeg := *seg
+		foldedSeg.Const = utils.ToLower(seg.Const)
+		foldedSeg.ComparePart = utils.ToLower(seg.ComparePart)
+		folded.segs[i] = &foldedSeg
+	}
+	return folded
+}
+
+// applyCase replaces the constant parts of the path with the ones of the route,
+// using the parameter values of a case-insensitive match of the path
+func (routeParser *routeParser) applyCase(path string, params *[maxParams]string) string {
+	fixed := []byte(path)
+	pos, paramsIterator := 0, 0
+	for _, seg := range routeParser.segs {
+		if pos > len(fixed) {
+			return ""
+		}
+		if seg.IsParam {
+			pos += len(params[paramsIterator])
+			paramsIterator++
+			continue
+		}
+		pos += copy(fixed[pos:], seg.Const)
+	}
+	return string(fixed)
+}
+
 // addParameterMetaInfo add important meta information to the parameter segments
 // to simplify the search for the end of the parameter
 func addParameterMetaInfo(segs []*routeSegment) []*routeSegment {
@@ -284,7 +350,7 @@ func (*routeParser) analyseConstantPart(pattern string, nextParamPosition int) (
 }
 
 // analyseParameterPart find the parameter end and create the route segment
-func (routeParser *routeParser) analyseParameterPart(pattern string) (string, *routeSegment) {
+func (routeParser *routeParser) analyseParameterPart(pattern string, customConstraints []CustomConstraint) (string, *routeSegment) {
 	isWildCard := pattern[0] == wildcardParam
 	isPlusParam := pattern[0] == plusParam
 
@@ -338,11 +404,8 @@ func (routeParser *routeParser) analyseParameterPart(pattern string) (string, *r
 				// remove escapes from data
 				if constraint.ID != regexConstraint {
 					constraint.Data = splitNonEscaped(c[start+1:end], string(parameterConstraintDataSeparatorChars))
-					if len(constraint.Data) == 1 {
-						constraint.Data[0] = RemoveEscapeChar(constraint.Data[0])
-					} else if len(constraint.Data) == 2 { // This is fine, we simply expect two parts
-						constraint.Data[0] = RemoveEscapeChar(constraint.Data[0])
-						constraint.Data[1] = RemoveEscapeChar(constraint.Data[1])
+					for i := range constraint.Data {
+						constraint.Data[i] = RemoveEscapeChar(constraint.Data[i])
 					}
 				}
 
@@ -352,12 +415,12 @@ func (routeParser *routeParser) analyseParameterPart(pattern string) (string, *r
 					constraint.RegexCompiler = regexp.MustCompile(constraint.Data[0])
 				}
 
-				constraints = append(constraints, constraint)
+				constraints = append(constraints, assignCustomConstraint(constraint, c[:start], customConstraints))
 			} else {
-				constraints = append(constraints, &Constraint{
+				constraints = append(constraints, assignCustomConstraint(&Constraint{
 					ID:   getParamConstraintType(c),
 					Data: []string{},
-				})
+				}, c, customConstraints))
 			}
 		}
 
@@ -642,6 +705,60 @@ func getParamConstraintType(constraintPart string) TypeConstraint {
 	}
 }
 
+// assignCustomConstraint assigns the custom constraint with the given name to
+// the constraint if it is not a built-in one, the arguments are validated once here.
+// It panics for unknown names, which would leave the parameter unrestricted.
+func assignCustomConstraint(constraint *Constraint, name string, customConstraints []CustomConstraint) *Constraint {
+	if constraint.ID != noConstraint {
+		return constraint
+	}
+	custom := findCustomConstraint(name, customConstraints)
+	if custom == nil {
+		panic(fmt.Sprintf("route: unknown constraint %q", name))
+	}
+	// empty parentheses mean no arguments
+	if len(constraint.Data) == 1 && constraint.Data[0] == "" {
+		constraint.Data = []string{}
+	}
+	if validator, ok := custom.(CustomConstraintValidator); ok {
+		if err := validator.ValidateArgs(constraint.Data); err != nil {
+			panic(fmt.Sprintf("route: invalid arguments for constraint %q: %v", name, err))
+		}
+	}
+	constraint.ID = customConstraint
+	constraint.CustomConstraint = custom
+
+	return constraint
+}
+
+// findCustomConstraint returns the custom constraint with the given name or nil,
+// the names are case-insensitive
+func findCustomConstraint(name string, customConstraints []CustomConstraint) CustomConstraint {
+	for _, custom := range customConstraints {
+		if utils.EqualFold(custom.Name(), name) {
+			return custom
+		}
+	}
+	return nil
+}
+
+// validateCustomConstraint panics if the custom constraint can't be used in route patterns
+func validateCustomConstraint(custom CustomConstraint, customConstraints []CustomConstraint) {
+	if custom == nil {
+		panic("route: custom constraint must not be nil")
+	}
+	name := custom.Name()
+	if name == "" || strings.ContainsAny(name, "<>;()\\") {
+		panic(fmt.Sprintf("route: invalid custom constraint name %q", name))
+	}
+	if getParamConstraintType(utils.ToLower(name)) != noConstraint {
+		panic(fmt.Sprintf("route: custom constraint %q conflicts with a built-in constraint", name))
+	}
+	if findCustomConstraint(name, customConstraints) != nil {
+		panic(fmt.Sprintf("route: custom constraint %q is already registered", name))
+	}
+}
+
 //nolint:errcheck // TODO: Properly check _all_ errors in here, log them & immediately return
 func (c *Constraint) CheckConstraint(param string) bool {
 	var err error
@@ -734,6 +851,8 @@ func (c *Constraint) CheckConstraint(param string) bool {
 		if match := c.RegexCompiler.MatchString(param); !match {
 			return false
 		}
+	case customConstraint:
+		return c.CustomConstraint.Execute(param, c.Data...)
 	}
 
 	return err == nil
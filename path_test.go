@@ -5,7 +5,9 @@
 package fiber
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -171,6 +173,94 @@ func Test_RoutePatternMatch(t *testing.T) {
 	}
 }
 
+// ulidConstraint matches ULIDs in canonical Crockford base32 encoding
+type ulidConstraint struct{}
+
+func (ulidConstraint) Name() string {
+	return "ulid"
+}
+
+func (ulidConstraint) Execute(param string, _ ...string) bool {
+	if len(param) != 26 || param[0] > '7' {
+		return false
+	}
+	for i := 0; i < len(param); i++ {
+		if !strings.ContainsRune("0123456789ABCDEFGHJKMNPQRSTVWXYZabcdefghjkmnpqrstvwxyz", rune(param[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// prefixConstraint matches parameters starting with one of the given prefixes
+type prefixConstraint struct{}
+
+func (prefixConstraint) Name() string {
+	return "prefix"
+}
+
+func (prefixConstraint) Execute(param string, args ...string) bool {
+	for _, prefix := range args {
+		if strings.HasPrefix(param, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (prefixConstraint) ValidateArgs(args []string) error {
+	if len(args) == 0 {
+		return errors.New("at least one prefix is required")
+	}
+	return nil
+}
+
+// go test -race -run Test_Path_CustomConstraint
+func Test_Path_CustomConstraint(t *testing.T) {
+	t.Parallel()
+	customConstraints := []CustomConstraint{ulidConstraint{}, prefixConstraint{}}
+
+	testCases := []struct {
+		pattern string
+		url     string
+		match   bool
+	}{
+		{pattern: "/users/:id<ulid>", url: "/users/01ARZ3NDEKTSV4RRFFQ69G5FAV", match: true},
+		{pattern: "/users/:id<ulid>", url: "/users/01ARZ3NDEKTSV4RRFFQ69G5FA", match: false},
+		{pattern: "/users/:id<ulid>", url: "/users/81ARZ3NDEKTSV4RRFFQ69G5FAV", match: false},
+		{pattern: "/users/:id<ulid>?", url: "/users", match: true},
+		{pattern: "/keys/:key<prefix(usr_,org\\,)>", url: "/keys/usr_123", match: true},
+		{pattern: "/keys/:key<prefix(usr_,org\\,)>", url: "/keys/org,123", match: true},
+		{pattern: "/keys/:key<prefix(usr_,org\\,)>", url: "/keys/org_123", match: false},
+		{pattern: "/keys/:key<prefix(k);maxLen(4)>", url: "/keys/k123", match: true},
+		{pattern: "/keys/:key<prefix(k);maxLen(4)>", url: "/keys/k1234", match: false},
+		{pattern: "/keys/:key<minLen(2);prefix(k)>", url: "/keys/x123", match: false},
+	}
+
+	var ctxParams [maxParams]string
+	for _, tc := range testCases {
+		parser := parseRoute(tc.pattern, customConstraints...)
+		require.Equal(t, tc.match, parser.getMatch(tc.url, tc.url, &ctxParams, false), "route: '%s', url: '%s'", tc.pattern, tc.url)
+		require.Equal(t, tc.match, RoutePatternMatch(tc.url, tc.pattern, Config{CaseSensitive: true, CustomConstraints: customConstraints}),
+			"route: '%s', url: '%s'", tc.pattern, tc.url)
+	}
+
+	// unknown constraints would leave the parameter unrestricted
+	require.PanicsWithValue(t, `route: unknown constraint "ulid"`, func() {
+		RoutePatternMatch("/users/abc", "/users/:id<ulid>")
+	})
+	require.PanicsWithValue(t, `route: unknown constraint "bool(("`, func() {
+		RoutePatternMatch("/api/v1/true", "/api/v1/:param<int;bool((>")
+	})
+	require.PanicsWithValue(t, `route: unknown constraint "int\\;range"`, func() {
+		RoutePatternMatch("/api/v1/25", `/api/v1/:param<int\;range(10,30)>`)
+	})
+
+	require.PanicsWithValue(t, `route: invalid arguments for constraint "prefix": at least one prefix is required`, func() {
+		parseRoute("/keys/:key<prefix>", customConstraints...)
+	})
+}
+
 func Test_Utils_GetTrimmedParam(t *testing.T) {
 	t.Parallel()
 	res := GetTrimmedParam("")
@@ -606,14 +606,6 @@ func init() {
 					{url: "/api/v1/2022/08-27", params: nil, match: false},
 				},
 			},
-			{
-				pattern: "/api/v1/:param<int;bool((>",
-				testCases: []routeTestCase{
-					{url: "/api/v1/entity", params: nil, match: false},
-					{url: "/api/v1/8728382", params: []string{"8728382"}, match: true},
-					{url: "/api/v1/true", params: nil, match: false},
-				},
-			},
 			{
 				pattern: "/api/v1/:param<int;max(3000)>",
 				testCases: []routeTestCase{
@@ -641,15 +633,6 @@ func init() {
 					{url: "/api/v1/true", params: nil, match: false},
 				},
 			},
-			{
-				pattern: `/api/v1/:param<int\;range(10,30)>`,
-				testCases: []routeTestCase{
-					{url: "/api/v1/entity", params: []string{"entity"}, match: true},
-					{url: "/api/v1/87283827683", params: []string{"87283827683"}, match: true},
-					{url: "/api/v1/25", params: []string{"25"}, match: true},
-					{url: "/api/v1/true", params: []string{"true"}, match: true},
-				},
-			},
 			{
 				pattern: `/api/v1/:param<range(10\,30,1500)>`,
 				testCases: []routeTestCase{
@@ -28,7 +28,8 @@ var _ (Register) = (*Registering)(nil)
 
 // Registering struct
 type Registering struct {
-	app *App
+	app   *App
+	group *Group
 
 	path string
 }
@@ -48,14 +49,14 @@ type Registering struct {
 //
 // This method will match all HTTP verbs: GET, POST, PUT, HEAD etc...
 func (r *Registering) All(handler Handler, middleware ...Handler) Register {
-	r.app.register([]string{methodUse}, r.path, nil, handler, middleware...)
+	r.app.register([]string{methodUse}, r.path, r.group, handler, middleware...)
 	return r
 }
 
 // Get registers a route for GET methods that requests a representation
 // of the specified resource. Requests using GET should only retrieve data.
 func (r *Registering) Get(handler Handler, middleware ...Handler) Register {
-	r.app.Add([]string{MethodGet}, r.path, handler, middleware...)
+	r.app.register([]string{MethodGet}, r.path, r.group, handler, middleware...)
 	return r
 }
 
@@ -108,13 +109,13 @@ func (r *Registering) Patch(handler Handler, middleware ...Handler) Register {
 
 // Add allows you to specify multiple HTTP methods to register a route.
 func (r *Registering) Add(methods []string, handler Handler, middleware ...Handler) Register {
-	r.app.register(methods, r.path, nil, handler, middleware...)
+	r.app.register(methods, r.path, r.group, handler, middleware...)
 	return r
 }
 
 // Static will create a file server serving static files
 func (r *Registering) Static(root string, config ...Static) Register {
-	r.app.registerStatic(r.path, root, config...)
+	r.app.registerStatic(r.path, root, r.group, config...)
 	return r
 }
 
@@ -122,7 +123,7 @@ func (r *Registering) Static(root string, config ...Static) Register {
 // the path in the current instance as its prefix.
 func (r *Registering) Route(path string) Register {
 	// Create new group
-	route := &Registering{app: r.app, path: getGroupPath(r.path, path)}
+	route := &Registering{app: r.app, group: r.group, path: getGroupPath(r.path, path)}
 
 	return route
 }
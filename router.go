@@ -7,6 +7,7 @@ package fiber
 import (
 	"fmt"
 	"html"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -37,6 +38,9 @@ type Router interface {
 
 	Group(prefix string, handlers ...Handler) Router
 
+	Domain(host string) Router
+	When(conditions ...RouteCondition) Router
+
 	Route(path string) Register
 
 	Name(name string) Router
@@ -46,22 +50,26 @@ type Router interface {
 type Route struct {
 	// ### important: always keep in sync with the copy method "app.copyRoute" ###
 	// Data for routing
-	pos         uint32      // Position in stack -> important for the sort of the matched routes
-	use         bool        // USE matches path prefixes
-	mount       bool        // Indicated a mounted app on a specific route
-	star        bool        // Path equals '*'
-	root        bool        // Path equals '/'
-	path        string      // Prettified path
-	routeParser routeParser // Parameter parser
-	group       *Group      // Group instance. used for routes in groups
+	pos         uint32           // Position in stack -> important for the sort of the matched routes
+	use         bool             // USE matches path prefixes
+	mount       bool             // Indicated a mounted app on a specific route
+	star        bool             // Path equals '*'
+	root        bool             // Path equals '/'
+	path        string           // Prettified path
+	routeParser routeParser      // Parameter parser
+	foldedCase  *routeParser     // Parameter parser with lowercased constant parts, only set with RedirectFixedCase
+	domain      *routeDomain     // Host pattern of routes registered with Domain
+	conditions  []RouteCondition // Additional conditions of routes registered with When
+	group       *Group           // Group instance. used for routes in groups
 
 	// Public fields
 	Method string `json:"method"` // HTTP method
 	Name   string `json:"name"`   // Route's name
 	//nolint:revive // Having both a Path (uppercase) and a path (lowercase) is fine
-	Path     string    `json:"path"`   // Original registered route path
-	Params   []string  `json:"params"` // Case sensitive param keys
-	Handlers []Handler `json:"-"`      // Ctx handlers
+	Path     string        `json:"path"`   // Original registered route path
+	Params   []string      `json:"params"` // Case sensitive param keys
+	Handlers []Handler     `json:"-"`      // Ctx handlers
+	Types    *HandlerTypes `json:"-"`      // Request and response types of the handler registered with AddTyped
 }
 
 func (r *Route) match(detectionPath, path string, params *[maxParams]string) bool {
@@ -77,8 +85,8 @@ func (r *Route) match(detectionPath, path string, params *[maxParams]string) boo
 		}
 		return true
 	}
-	// Does this route have parameters
-	if len(r.Params) > 0 {
+	// Does this route have parameters, apart from the ones of the domain
+	if len(r.Params) > r.domain.paramsCount() {
 		// Match params
 		if match := r.routeParser.getMatch(detectionPath, path, params, r.use); match {
 			// Get params from the path detectionPath
@@ -101,10 +109,7 @@ func (r *Route) match(detectionPath, path string, params *[maxParams]string) boo
 
 func (app *App) nextCustom(c CustomCtx) (bool, error) { //nolint: unparam // bool param might be useful for testing
 	// Get stack length
-	tree, ok := app.treeStack[c.getMethodINT()][c.getTreePath()]
-	if !ok {
-		tree = app.treeStack[c.getMethodINT()][""]
-	}
+	tree := c.getTreeStack()[c.getMethodINT()].find(c.getDetectionPath())
 	lenr := len(tree) - 1
 
 	// Loop over the route stack starting from previous index
@@ -115,8 +120,9 @@ func (app *App) nextCustom(c CustomCtx) (bool, error) { //nolint: unparam // boo
 		// Get *Route
 		route := tree[c.getIndexRoute()]
 
-		// Check if it matches the request path
-		match := route.match(c.getDetectionPath(), c.Path(), c.getValues())
+		// Check if it matches the request path, hostname and conditions
+		match := route.match(c.getDetectionPath(), c.Path(), c.getValues()) &&
+			route.matchDomain(c, c.getValues()) && route.matchConditions(c)
 
 		// No match, next route
 		if !match {
@@ -141,7 +147,22 @@ func (app *App) nextCustom(c CustomCtx) (bool, error) { //nolint: unparam // boo
 
 	// If no match, scan stack again if other methods match the request
 	// Moved from app.handler because middleware may break the route chain
+	if !c.getMatched() {
+		// Reject requests only failing the media type conditions of routes
+		if app.conditionsNotAcceptable(c) {
+			return false, ErrNotAcceptable
+		}
+		// Redirect to the canonical path of a matching route if enabled
+		if location := app.redirectLocation(c); location != "" {
+			return false, c.Redirect().Status(redirectStatus(c.Method())).To(location)
+		}
+	}
 	if !c.getMatched() && app.methodExistCustom(c) {
+		// Answer OPTIONS requests with the allowed methods if enabled
+		if app.config.EnableAutoOptions && c.Method() == MethodOptions {
+			app.autoOptions(c)
+			return false, nil
+		}
 		err = ErrMethodNotAllowed
 	}
 	return false, err
@@ -149,10 +170,7 @@ func (app *App) nextCustom(c CustomCtx) (bool, error) { //nolint: unparam // boo
 
 func (app *App) next(c *DefaultCtx) (bool, error) {
 	// Get stack length
-	tree, ok := app.treeStack[c.methodINT][c.treePath]
-	if !ok {
-		tree = app.treeStack[c.methodINT][""]
-	}
+	tree := c.treeStack[c.methodINT].find(c.detectionPath)
 	lenTree := len(tree) - 1
 
 	// Loop over the route stack starting from previous index
@@ -170,8 +188,8 @@ func (app *App) next(c *DefaultCtx) (bool, error) {
 			continue
 		}
 
-		// Check if it matches the request path
-		match = route.match(c.detectionPath, c.path, &c.values)
+		// Check if it matches the request path, hostname and conditions
+		match = route.match(c.detectionPath, c.path, &c.values) && route.matchDomain(c, &c.values) && route.matchConditions(c)
 		if !match {
 			// No match, next route
 			continue
@@ -194,9 +212,24 @@ func (app *App) next(c *DefaultCtx) (bool, error) {
 
 	// If c.Next() does not match, return 404
 	err := NewError(StatusNotFound, "Cannot "+c.method+" "+html.EscapeString(c.pathOriginal))
+	if !c.matched {
+		// Reject requests only failing the media type conditions of routes
+		if app.conditionsNotAcceptable(c) {
+			return false, ErrNotAcceptable
+		}
+		// Redirect to the canonical path of a matching route if enabled
+		if location := app.redirectLocation(c); location != "" {
+			return false, c.Redirect().Status(redirectStatus(c.method)).To(location)
+		}
+	}
 	if !c.matched && app.methodExist(c) {
 		// If no match, scan stack again if other methods match the request
 		// Moved from app.handler because middleware may break the route chain
+		// Answer OPTIONS requests with the allowed methods if enabled
+		if app.config.EnableAutoOptions && c.method == MethodOptions {
+			app.autoOptions(c)
+			return false, nil
+		}
 		err = ErrMethodNotAllowed
 	}
 	return false, err
@@ -246,7 +279,7 @@ func (app *App) requestHandler(rctx *fasthttp.RequestCtx) {
 	}
 }
 
-func (app *App) addPrefixToRoute(prefix string, route *Route) *Route {
+func (app *App) addPrefixToRoute(prefix string, route *Route, customConstraints ...CustomConstraint) *Route {
 	prefixedPath := getGroupPath(prefix, route.Path)
 	prettyPath := prefixedPath
 	// Case-sensitive routing, all to lowercase
@@ -258,9 +291,19 @@ func (app *App) addPrefixToRoute(prefix string, route *Route) *Route {
 		prettyPath = strings.TrimRight(prettyPath, "/")
 	}
 
+	// Constraints of the sub app take precedence over the ones of the app
+	if len(customConstraints) > 0 {
+		merged := make([]CustomConstraint, 0, len(customConstraints)+len(app.config.CustomConstraints))
+		merged = append(merged, customConstraints...)
+		customConstraints = append(merged, app.config.CustomConstraints...)
+	} else {
+		customConstraints = app.config.CustomConstraints
+	}
+
 	route.Path = prefixedPath
 	route.path = RemoveEscapeChar(prettyPath)
-	route.routeParser = parseRoute(prettyPath)
+	route.routeParser = parsePrettyRoute(prefixedPath, app.config.CaseSensitive, app.config.StrictRouting, customConstraints...)
+	route.foldedCase = app.foldedCaseParser(&route.routeParser)
 	route.root = false
 	route.star = false
 
@@ -278,6 +321,9 @@ func (*App) copyRoute(route *Route) *Route {
 		// Path data
 		path:        route.path,
 		routeParser: route.routeParser,
+		foldedCase:  route.foldedCase,
+		domain:      route.domain,
+		conditions:  route.conditions,
 
 		// misc
 		pos: route.pos,
@@ -288,10 +334,17 @@ func (*App) copyRoute(route *Route) *Route {
 		Name:     route.Name,
 		Method:   route.Method,
 		Handlers: route.Handlers,
+		Types:    route.Types,
 	}
 }
 
 func (app *App) register(methods []string, pathRaw string, group *Group, handler Handler, middleware ...Handler) {
+	app.registerTyped(methods, pathRaw, group, nil, handler, middleware...)
+}
+
+// registerTyped registers the routes like register, with the types of the
+// handler created with Typed, see AddTyped
+func (app *App) registerTyped(methods []string, pathRaw string, group *Group, types *HandlerTypes, handler Handler, middleware ...Handler) {
 	handlers := middleware
 	if handler != nil {
 		handlers = append(handlers, handler)
@@ -335,8 +388,8 @@ func (app *App) register(methods []string, pathRaw string, group *Group, handler
 		// Is path a root slash?
 		isRoot := pathPretty == "/"
 		// Parse path parameters
-		parsedRaw := parseRoute(pathRaw)
-		parsedPretty := parseRoute(pathPretty)
+		parsedRaw := parseRoute(pathRaw, app.config.CustomConstraints...)
+		parsedPretty := parsePrettyRoute(pathRaw, app.config.CaseSensitive, app.config.StrictRouting, app.config.CustomConstraints...)
 
 		// Create route metadata without pointer
 		route := Route{
@@ -349,6 +402,7 @@ func (app *App) register(methods []string, pathRaw string, group *Group, handler
 			// Path data
 			path:        RemoveEscapeChar(pathPretty),
 			routeParser: parsedPretty,
+			foldedCase:  app.foldedCaseParser(&parsedPretty),
 			Params:      parsedRaw.params,
 
 			// Group data
@@ -358,6 +412,12 @@ func (app *App) register(methods []string, pathRaw string, group *Group, handler
 			Path:     pathRaw,
 			Method:   method,
 			Handlers: handlers,
+			Types:    types,
+		}
+		// Routes of domain groups only match their hostname
+		if group != nil {
+			route.setDomain(group.domain)
+			route.conditions = group.conditions
 		}
 		// Increment global handler count
 		atomic.AddUint32(&app.handlersCount, uint32(len(handlers)))
@@ -375,9 +435,12 @@ func (app *App) register(methods []string, pathRaw string, group *Group, handler
 			app.addRoute(method, &route, isMount)
 		}
 	}
+
+	// Serve the new routes right away if the app is already started
+	app.rebuildTree()
 }
 
-func (app *App) registerStatic(prefix, root string, config ...Static) {
+func (app *App) registerStatic(prefix, root string, group *Group, config ...Static) {
 	// For security, we want to restrict to the current work directory.
 	if root == "" {
 		root = "."
@@ -507,12 +570,20 @@ func (app *App) registerStatic(prefix, root string, config ...Static) {
 		Path:     prefix,
 		Handlers: []Handler{handler},
 	}
+	// Routes of domain groups only match their hostname
+	if group != nil {
+		route.setDomain(group.domain)
+		route.conditions = group.conditions
+	}
 	// Increment global handler count
 	atomic.AddUint32(&app.handlersCount, 1)
 	// Add route to stack
 	app.addRoute(MethodGet, &route)
 	// Add HEAD route
 	app.addRoute(MethodHead, &route)
+
+	// Serve the new routes right away if the app is already started
+	app.rebuildTree()
 }
 
 func (app *App) addRoute(method string, route *Route, isMounted ...bool) {
@@ -525,28 +596,85 @@ func (app *App) addRoute(method string, route *Route, isMounted ...bool) {
 	// Get unique HTTP method identifier
 	m := app.methodInt(method)
 
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
 	// prevent identically route registration
 	l := len(app.stack[m])
-	if l > 0 && app.stack[m][l-1].Path == route.Path && route.use == app.stack[m][l-1].use && !route.mount && !app.stack[m][l-1].mount {
-		preRoute := app.stack[m][l-1]
-		preRoute.Handlers = append(preRoute.Handlers, route.Handlers...)
+	if l > 0 && app.stack[m][l-1].Path == route.Path && route.use == app.stack[m][l-1].use && !route.mount && !app.stack[m][l-1].mount &&
+		app.stack[m][l-1].domainPattern() == route.domainPattern() && app.stack[m][l-1].sameConditions(route) {
+		// replace the previous route by a copy, it might be used by requests being served
+		preRoute := *app.stack[m][l-1]
+		preRoute.Handlers = append(preRoute.Handlers[:len(preRoute.Handlers):len(preRoute.Handlers)], route.Handlers...)
+		if route.Types != nil {
+			preRoute.Types = route.Types
+		}
+		app.stack[m][l-1] = &preRoute
 	} else {
 		// Increment global route position
 		route.pos = atomic.AddUint32(&app.routesCount, 1)
 		route.Method = method
 		// Add route to the stack
 		app.stack[m] = append(app.stack[m], route)
-		app.routesRefreshed = true
 	}
+	app.routesRefreshed = true
 
 	// Execute onRoute hooks & change latestRoute if not adding mounted route
 	if !mounted {
-		app.mutex.Lock()
 		app.latestRoute = route
 		if err := app.hooks.executeOnRouteHooks(*route); err != nil {
 			panic(err)
 		}
-		app.mutex.Unlock()
+	}
+}
+
+// removeRoutes removes the matching routes of the given methods, or of all
+// methods if none is given, and rebuilds the tree if the app is started
+func (app *App) removeRoutes(match func(route *Route) bool, methods []string) {
+	// Select the HTTP methods
+	selected := make([]bool, len(app.config.RequestMethods))
+	for _, method := range methods {
+		m := app.methodInt(utils.ToUpper(method))
+		if m == -1 {
+			panic(fmt.Sprintf("remove: invalid http method %s\n", method))
+		}
+		selected[m] = true
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	for m := range app.config.RequestMethods {
+		if len(methods) > 0 && !selected[m] {
+			continue
+		}
+		// create a new stack, the current one might be read by requests being served
+		var stack []*Route
+		for i, route := range app.stack[m] {
+			if route.use || route.mount || !match(route) {
+				if stack != nil {
+					stack = append(stack, route)
+				}
+				continue
+			}
+			if stack == nil {
+				stack = append(make([]*Route, 0, len(app.stack[m])), app.stack[m][:i]...)
+			}
+			// Decrement global handler count
+			atomic.AddUint32(&app.handlersCount, ^uint32(len(route.Handlers)-1))
+			if err := app.hooks.executeOnRouteRemoveHooks(*route); err != nil {
+				panic(err)
+			}
+		}
+		if stack != nil {
+			app.stack[m] = stack
+			app.routesRefreshed = true
+		}
+	}
+
+	// Stop serving the removed routes right away if the app is already started
+	if app.treeBuilt {
+		app.buildTree()
 	}
 }
 
@@ -556,34 +684,300 @@ func (app *App) buildTree() *App {
 		return app
 	}
 
-	// loop all the methods and stacks and create the prefix tree
+	// loop all the methods and stacks and create the radix tree
+	treeStack := make([]*routeTree, len(app.config.RequestMethods))
 	for m := range app.config.RequestMethods {
-		tsMap := make(map[string][]*Route)
+		tree := &routeTree{}
 		for _, route := range app.stack[m] {
-			treePath := ""
-			if len(route.routeParser.segs) > 0 && len(route.routeParser.segs[0].Const) >= 3 {
-				treePath = route.routeParser.segs[0].Const[:3]
+			tree.insert(route.treePrefix(), route)
+		}
+		// merge the routes of the parent nodes and sort everything
+		tree.build(nil)
+		treeStack[m] = tree
+	}
+	// swap the trees at once, requests being served keep the trees they started with
+	app.treeStack.Store(&treeStack)
+	app.routesRefreshed = false
+
+	return app
+}
+
+// rebuildTree applies the route changes to the tree if the app is already started
+func (app *App) rebuildTree() {
+	app.mutex.Lock()
+	if app.treeBuilt {
+		app.buildTree()
+	}
+	app.mutex.Unlock()
+}
+
+// treePrefix returns the constant prefix of all paths matched by the route,
+// which is its key in the route tree
+func (r *Route) treePrefix() string {
+	// '*' and the root middleware match any path
+	if r.star || (r.use && r.root) {
+		return ""
+	}
+	// without parameters the path is matched exactly or as a prefix
+	if len(r.Params) == r.domain.paramsCount() {
+		return r.path
+	}
+	segs := r.routeParser.segs
+	if len(segs) == 0 || segs[0].IsParam {
+		return ""
+	}
+	// the slash in front of an optional segment may be missing
+	if segs[0].HasOptionalSlash {
+		return segs[0].Const[:len(segs[0].Const)-1]
+	}
+	return segs[0].Const
+}
+
+// routeTree is a radix tree node holding the routes of one method
+type routeTree struct {
+	prefix   string       // Path part of the node, relative to its parent
+	indices  string       // First characters of the children prefixes
+	children []*routeTree // Child nodes, in the order of indices
+	routes   []*Route     // Routes whose prefix ends at this node
+	stack    []*Route     // Routes of this node and its parents, sorted by position
+}
+
+// insert adds the route with the given prefix to the tree
+func (t *routeTree) insert(prefix string, route *Route) {
+	node := t
+	for prefix != "" {
+		i := strings.IndexByte(node.indices, prefix[0])
+		// no child shares the prefix, create a new leaf
+		if i == -1 {
+			node.indices += prefix[:1]
+			node.children = append(node.children, &routeTree{prefix: prefix, routes: []*Route{route}})
+			return
+		}
+		child := node.children[i]
+		// find the longest common prefix
+		l := 0
+		for l < len(prefix) && l < len(child.prefix) && prefix[l] == child.prefix[l] {
+			l++
+		}
+		// split the child at the end of the common prefix
+		if l < len(child.prefix) {
+			split := &routeTree{
+				prefix:   child.prefix[:l],
+				indices:  child.prefix[l : l+1],
+				children: []*routeTree{child},
 			}
-			// create tree stack
-			tsMap[treePath] = append(tsMap[treePath], route)
+			child.prefix = child.prefix[l:]
+			node.children[i] = split
+			child = split
 		}
-		app.treeStack[m] = tsMap
+		node = child
+		prefix = prefix[l:]
 	}
+	node.routes = append(node.routes, route)
+}
 
-	// loop the methods and tree stacks and add global stack and sort everything
-	for m := range app.config.RequestMethods {
-		tsMap := app.treeStack[m]
-		for treePart := range tsMap {
-			if treePart != "" {
-				// merge global tree routes in current tree stack
-				tsMap[treePart] = uniqueRouteStack(append(tsMap[treePart], tsMap[""]...))
+// build merges the routes of the parent nodes into the stack of every node
+func (t *routeTree) build(parent []*Route) {
+	t.stack = parent
+	if len(t.routes) > 0 {
+		stack := make([]*Route, 0, len(parent)+len(t.routes))
+		stack = uniqueRouteStack(append(append(stack, parent...), t.routes...))
+		// sort the stack with the positions
+		sort.Slice(stack, func(i, j int) bool { return stack[i].pos < stack[j].pos })
+		t.stack = stack
+	}
+	for _, child := range t.children {
+		child.build(t.stack)
+	}
+}
+
+// find returns the routes which can match the detection path, in the order
+// they were registered
+func (t *routeTree) find(detectionPath string) []*Route {
+	// the tree is not built as long as no route was registered
+	if t == nil {
+		return nil
+	}
+	node := t
+	for detectionPath != "" {
+		i := strings.IndexByte(node.indices, detectionPath[0])
+		if i == -1 {
+			break
+		}
+		child := node.children[i]
+		if !strings.HasPrefix(detectionPath, child.prefix) {
+			break
+		}
+		node = child
+		detectionPath = detectionPath[len(child.prefix):]
+	}
+	return node.stack
+}
+
+// walk calls fn for the routes of the node and its children
+func (t *routeTree) walk(fn func(route *Route)) {
+	if t == nil {
+		return
+	}
+	for _, route := range t.routes {
+		fn(route)
+	}
+	for _, child := range t.children {
+		child.walk(fn)
+	}
+}
+
+// redirectStatus returns the status code of redirects to canonical paths,
+// other methods than GET and HEAD keep their method and body with 308
+func redirectStatus(method string) int {
+	if method == MethodGet || method == MethodHead {
+		return StatusMovedPermanently
+	}
+	return StatusPermanentRedirect
+}
+
+// redirectLocation returns the location of the canonical path for a request without
+// matching route, if a route matches the path after cleaning it, toggling its trailing
+// slash or fixing its case, depending on the redirect options of the config.
+// The query string is preserved. Without such a route an empty string is returned.
+func (app *App) redirectLocation(c CustomCtx) string {
+	cfg := &app.config
+	redirectSlash := cfg.RedirectTrailingSlash && cfg.StrictRouting
+	redirectCase := cfg.RedirectFixedCase && cfg.CaseSensitive
+	if !redirectSlash && !redirectCase && !cfg.RedirectCleanPath {
+		return ""
+	}
+
+	reqPath := c.Path()
+	if reqPath == "" || reqPath[0] != '/' {
+		return ""
+	}
+	tree := c.getTreeStack()[c.getMethodINT()]
+
+	candidates := make([]string, 1, 2)
+	candidates[0] = reqPath
+	if cfg.RedirectCleanPath {
+		candidates[0] = cleanPath(reqPath)
+	}
+	if redirectSlash && candidates[0] != "/" {
+		if trimmed := strings.TrimRight(candidates[0], "/"); trimmed != candidates[0] {
+			if trimmed != "" {
+				candidates = append(candidates, trimmed)
 			}
-			// sort tree slices with the positions
-			slc := tsMap[treePart]
-			sort.Slice(slc, func(i, j int) bool { return slc[i].pos < slc[j].pos })
+		} else {
+			candidates = append(candidates, candidates[0]+"/")
 		}
 	}
-	app.routesRefreshed = false
 
-	return app
+	location := ""
+	for _, candidate := range candidates {
+		if candidate != reqPath && app.routeExists(c, tree, candidate) {
+			location = candidate
+			break
+		}
+	}
+	if location == "" && redirectCase {
+		for _, candidate := range candidates {
+			if fixed := app.fixedCasePath(c, tree, candidate); fixed != "" && fixed != reqPath {
+				location = fixed
+				break
+			}
+		}
+	}
+	// A location starting with two slashes, or a slash and a backslash, would be
+	// taken as the host of another site by the client
+	if location == "" || strings.HasPrefix(location, "//") || strings.HasPrefix(location, "/\\") {
+		return ""
+	}
+
+	if queryString := c.Context().QueryArgs().QueryString(); len(queryString) > 0 {
+		location += "?" + string(queryString)
+	}
+	return location
+}
+
+// routeExists checks if a route of the tree, which is not a middleware, matches
+// the path and the hostname of the request
+func (app *App) routeExists(c CustomCtx, tree *routeTree, path string) bool {
+	detectionPath := app.detectionPath(path)
+
+	var values [maxParams]string
+	for _, route := range tree.find(detectionPath) {
+		if route.use || route.mount {
+			continue
+		}
+		if route.match(detectionPath, path, &values) && route.matchDomain(c, &values) {
+			return true
+		}
+	}
+	return false
+}
+
+// fixedCasePath returns the path in the case of the first route of the tree, which
+// matches it case-insensitively, or an empty string if there is no such route
+func (app *App) fixedCasePath(c CustomCtx, tree *routeTree, path string) string {
+	lowerPath := utils.ToLower(path)
+	lowerDetectionPath := app.detectionPath(lowerPath)
+
+	var fixed string
+	var fixedPos uint32
+	var values [maxParams]string
+	tree.walk(func(route *Route) {
+		if route.use || route.mount || route.star || (fixed != "" && route.pos > fixedPos) {
+			return
+		}
+		// quick check of the first constant part before folding the route
+		if segs := route.routeParser.segs; len(segs) > 0 && !segs[0].IsParam {
+			n := len(segs[0].Const)
+			if n > len(path) {
+				n = len(path)
+			}
+			if !utils.EqualFold(path[:n], segs[0].Const[:n]) {
+				return
+			}
+		}
+		if route.foldedCase == nil || !route.foldedCase.getMatch(lowerDetectionPath, path, &values, false) {
+			return
+		}
+		candidate := route.routeParser.applyCase(path, &values)
+		if candidate == "" {
+			return
+		}
+		detectionPath := app.detectionPath(candidate)
+		if route.match(detectionPath, candidate, &values) && route.matchDomain(c, &values) {
+			fixed, fixedPos = candidate, route.pos
+		}
+	})
+	return fixed
+}
+
+// foldedCaseParser returns the parser with lowercased constant parts used to fix
+// the case of paths, or nil if the app doesn't redirect to the fixed case
+func (app *App) foldedCaseParser(parser *routeParser) *routeParser {
+	if !app.config.RedirectFixedCase || !app.config.CaseSensitive {
+		return nil
+	}
+	folded := parser.foldCase()
+	return &folded
+}
+
+// detectionPath returns the path used for the route detection, like the one of the context
+func (app *App) detectionPath(path string) string {
+	if !app.config.CaseSensitive {
+		path = utils.ToLower(path)
+	}
+	if !app.config.StrictRouting && len(path) > 1 && path[len(path)-1] == '/' {
+		path = strings.TrimRight(path, "/")
+	}
+	return path
+}
+
+// cleanPath collapses multiple slashes and resolves "." and ".." elements of the
+// path, a trailing slash is kept
+func cleanPath(p string) string {
+	cleaned := path.Clean(p)
+	if cleaned != "/" && p[len(p)-1] == '/' {
+		cleaned += "/"
+	}
+	return cleaned
 }
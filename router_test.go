@@ -6,12 +6,14 @@
 package fiber
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http/httptest"
 	"os"
+	"sort"
 	"strings"
 	"testing"
 
@@ -490,6 +492,394 @@ func Test_Router_NotFound_HTML_Inject(t *testing.T) {
 	require.Equal(t, "Cannot DELETE /does/not/exist&lt;script&gt;alert(&#39;foo&#39;);&lt;/script&gt;", string(c.Response.Body()))
 }
 
+// go test -run Test_Router_Tree_Order
+func Test_Router_Tree_Order(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	trail := func(name string) Handler {
+		return func(c Ctx) error {
+			c.Append("X-Trail", name)
+			return c.Next()
+		}
+	}
+
+	app.Use(trail("use"))
+	app.Get("/api/users/:id<int>", trail("int"))
+	app.Use("/api", trail("use-api"))
+	app.Get("/api/users/:id?", trail("optional"))
+	app.Get("/api/users/*", trail("greedy"))
+	app.Get("/api/users/new", trail("const"))
+	app.Get("/:any", trail("param"))
+	app.Use(func(c Ctx) error {
+		return c.SendString(c.GetRespHeader("X-Trail"))
+	})
+
+	testCases := []struct {
+		path  string
+		trail string
+	}{
+		{path: "/api/users/42", trail: "use, int, use-api, optional, greedy"},
+		{path: "/api/users/new", trail: "use, use-api, optional, greedy, const"},
+		{path: "/api/users/42/posts", trail: "use, use-api, greedy"},
+		{path: "/api/users", trail: "use, use-api, optional, greedy"},
+		{path: "/api", trail: "use, use-api, param"},
+		{path: "/other", trail: "use, param"},
+		{path: "/", trail: "use"},
+	}
+
+	for _, tc := range testCases {
+		resp, err := app.Test(httptest.NewRequest(MethodGet, tc.path, nil))
+		require.NoError(t, err, "app.Test(req)")
+		require.Equal(t, StatusOK, resp.StatusCode, tc.path)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, tc.trail, string(body), tc.path)
+	}
+}
+
+// go test -run Test_Route_TreePrefix
+func Test_Route_TreePrefix(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	h := func(Ctx) error { return nil }
+
+	testCases := []struct {
+		path   string
+		prefix string
+		use    bool
+	}{
+		{path: "/", prefix: "/"},
+		{path: "*", prefix: ""},
+		{path: "/API/Users", prefix: "/api/users"},
+		{path: "/api/:id", prefix: "/api/"},
+		{path: "/api/:id?", prefix: "/api"},
+		{path: "/api/*", prefix: "/api"},
+		{path: "/api-:id", prefix: "/api-"},
+		{path: "/:id", prefix: "/"},
+		{path: "/:id?", prefix: ""},
+		{path: "/", prefix: "", use: true},
+		{path: "/api", prefix: "/api", use: true},
+	}
+
+	for _, tc := range testCases {
+		if tc.use {
+			app.Use(tc.path, h)
+		} else {
+			app.Get(tc.path, h)
+		}
+		require.Equal(t, tc.prefix, app.latestRoute.treePrefix(), tc.path)
+	}
+}
+
+type redirectTestCase struct {
+	method   string
+	target   string
+	code     int
+	location string
+}
+
+func testRedirects(t *testing.T, app *App, testCases []redirectTestCase) {
+	t.Helper()
+
+	for _, tc := range testCases {
+		resp, err := app.Test(httptest.NewRequest(tc.method, tc.target, nil))
+		require.NoError(t, err, "app.Test(req)")
+		require.Equal(t, tc.code, resp.StatusCode, tc.method+" "+tc.target)
+		require.Equal(t, tc.location, resp.Header.Get(HeaderLocation), tc.method+" "+tc.target)
+	}
+}
+
+// go test -run Test_Router_RedirectTrailingSlash
+func Test_Router_RedirectTrailingSlash(t *testing.T) {
+	t.Parallel()
+
+	app := New(Config{StrictRouting: true, RedirectTrailingSlash: true})
+	app.Use(func(c Ctx) error {
+		return c.Next()
+	})
+	app.Get("/users", testEmptyHandler)
+	app.Post("/users", testEmptyHandler)
+	app.Get("/posts/", testEmptyHandler)
+	app.Get("/files/:name", testEmptyHandler)
+	app.Domain("api.example.com").Get("/keys", testEmptyHandler)
+
+	testRedirects(t, app, []redirectTestCase{
+		{method: MethodGet, target: "/users", code: StatusOK},
+		{method: MethodGet, target: "/users/", code: StatusMovedPermanently, location: "/users"},
+		{method: MethodGet, target: "/users/?page=2&sort=name", code: StatusMovedPermanently, location: "/users?page=2&sort=name"},
+		{method: MethodHead, target: "/posts", code: StatusNotFound},
+		{method: MethodGet, target: "/posts", code: StatusMovedPermanently, location: "/posts/"},
+		{method: MethodPost, target: "/users/", code: StatusPermanentRedirect, location: "/users"},
+		{method: MethodGet, target: "/files/a.txt/", code: StatusMovedPermanently, location: "/files/a.txt"},
+		{method: MethodGet, target: "http://api.example.com/keys/", code: StatusMovedPermanently, location: "/keys"},
+		{method: MethodGet, target: "http://www.example.com/keys/", code: StatusNotFound},
+		{method: MethodGet, target: "/Users/", code: StatusMovedPermanently, location: "/Users"},
+		{method: MethodGet, target: "/unknown/", code: StatusNotFound},
+		{method: MethodPut, target: "/users/", code: StatusNotFound},
+	})
+
+	// redirects need strict routing
+	app = New(Config{RedirectTrailingSlash: true})
+	app.Get("/users", testEmptyHandler)
+	testRedirects(t, app, []redirectTestCase{
+		{method: MethodGet, target: "/users/", code: StatusOK},
+	})
+
+	// disabled by default
+	app = New(Config{StrictRouting: true})
+	app.Get("/users", testEmptyHandler)
+	testRedirects(t, app, []redirectTestCase{
+		{method: MethodGet, target: "/users/", code: StatusNotFound},
+	})
+}
+
+// go test -run Test_Router_RedirectTrailingSlash_OpenRedirect
+func Test_Router_RedirectTrailingSlash_OpenRedirect(t *testing.T) {
+	t.Parallel()
+
+	app := New(Config{StrictRouting: true, RedirectTrailingSlash: true})
+	app.Get("/:a?/:b", testEmptyHandler)
+	handler := app.Handler()
+
+	for _, target := range []string{"//evil.com/", "/\\evil.com/"} {
+		c := &fasthttp.RequestCtx{}
+		require.NoError(t, c.Request.Read(bufio.NewReader(strings.NewReader("GET "+target+" HTTP/1.1\r\nHost: example.com\r\n\r\n"))))
+		c.Request.URI().DisablePathNormalizing = true
+		handler(c)
+		// The client would take the host of another site from the location
+		require.Equal(t, StatusNotFound, c.Response.StatusCode(), target)
+		require.Equal(t, "", string(c.Response.Header.Peek(HeaderLocation)), target)
+	}
+}
+
+// go test -run Test_Router_RedirectFixedCase
+func Test_Router_RedirectFixedCase(t *testing.T) {
+	t.Parallel()
+
+	app := New(Config{CaseSensitive: true, StrictRouting: true, RedirectFixedCase: true, RedirectTrailingSlash: true})
+	app.Get("/Users/:id/Profile", testEmptyHandler)
+	app.Get("/about", testEmptyHandler)
+	app.Get("/docs/*", testEmptyHandler)
+	app.Delete("/Items/:id<int>", testEmptyHandler)
+
+	testRedirects(t, app, []redirectTestCase{
+		{method: MethodGet, target: "/Users/AbC/Profile", code: StatusOK},
+		{method: MethodGet, target: "/users/AbC/profile", code: StatusMovedPermanently, location: "/Users/AbC/Profile"},
+		{method: MethodGet, target: "/ABOUT?x=1", code: StatusMovedPermanently, location: "/about?x=1"},
+		{method: MethodGet, target: "/ABOUT/", code: StatusMovedPermanently, location: "/about"},
+		{method: MethodGet, target: "/DOCS/Guide", code: StatusMovedPermanently, location: "/docs/Guide"},
+		{method: MethodDelete, target: "/items/42", code: StatusPermanentRedirect, location: "/Items/42"},
+		{method: MethodDelete, target: "/items/abc", code: StatusNotFound},
+		{method: MethodGet, target: "/contact", code: StatusNotFound},
+	})
+
+	// redirects need case sensitive routing
+	app = New(Config{RedirectFixedCase: true})
+	app.Get("/about", testEmptyHandler)
+	testRedirects(t, app, []redirectTestCase{
+		{method: MethodGet, target: "/ABOUT", code: StatusOK},
+	})
+}
+
+// go test -run Test_Router_RedirectCleanPath
+func Test_Router_RedirectCleanPath(t *testing.T) {
+	t.Parallel()
+
+	app := New(Config{RedirectCleanPath: true})
+	app.Get("/a/b", testEmptyHandler)
+	app.Get("/static/*", testEmptyHandler)
+
+	testRedirects(t, app, []redirectTestCase{
+		{method: MethodGet, target: "/a/b", code: StatusOK},
+		{method: MethodGet, target: "http://example.com//a//b", code: StatusMovedPermanently, location: "/a/b"},
+		{method: MethodGet, target: "/a/./c/../b?q=1", code: StatusMovedPermanently, location: "/a/b?q=1"},
+		{method: MethodGet, target: "/a/../../a/b/", code: StatusMovedPermanently, location: "/a/b/"},
+		{method: MethodGet, target: "/a/c", code: StatusNotFound},
+	})
+
+	require.Equal(t, "/", cleanPath("/"))
+	require.Equal(t, "/", cleanPath("//"))
+	require.Equal(t, "/a/", cleanPath("/a//"))
+	require.Equal(t, "/b", cleanPath("/a/../b"))
+}
+
+// go test -run Test_App_RemoveRoute
+func Test_App_RemoveRoute(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	app.Use("/api", func(c Ctx) error {
+		c.Set("X-API", "true")
+		return c.Next()
+	})
+	app.Get("/api/users/:id", testSimpleHandler)
+	app.Post("/api/users/:id", testSimpleHandler)
+	app.Put("/api/users/:id", testSimpleHandler)
+	app.Get("/api/Feature/", testSimpleHandler)
+
+	resp, err := app.Test(httptest.NewRequest(MethodPost, "/api/users/1", nil))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, StatusOK, resp.StatusCode)
+	handlersCount := app.HandlersCount()
+
+	// Routes are removed while the app is started
+	app.RemoveRoute("api/users/:id", MethodPost, "put")
+	require.Equal(t, handlersCount-2, app.HandlersCount())
+
+	resp, err = app.Test(httptest.NewRequest(MethodPost, "/api/users/1", nil))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, StatusMethodNotAllowed, resp.StatusCode)
+	require.Equal(t, MethodGet, resp.Header.Get(HeaderAllow))
+	require.Equal(t, "true", resp.Header.Get("X-API"))
+
+	app.RemoveRoute("/api/feature")
+	resp, err = app.Test(httptest.NewRequest(MethodGet, "/api/feature", nil))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, StatusNotFound, resp.StatusCode)
+
+	// The route is replaced
+	app.RemoveRoute("/api/users/:id")
+	app.Get("/api/users/:id", func(c Ctx) error {
+		return c.SendString("user " + c.Params("id"))
+	})
+	resp, err = app.Test(httptest.NewRequest(MethodGet, "/api/users/1", nil))
+	require.NoError(t, err, "app.Test(req)")
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "user 1", string(body))
+
+	// Middleware is kept
+	require.Len(t, app.GetRoutes(true), 1)
+	require.Len(t, app.GetRoutes(), len(app.config.RequestMethods)+1)
+
+	require.PanicsWithValue(t, "remove: invalid http method JANE\n", func() {
+		app.RemoveRoute("/api/users/:id", "JANE")
+	})
+}
+
+// go test -run Test_App_RemoveRoute_Domain
+func Test_App_RemoveRoute_Domain(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	app.Domain("api.example.com").Get("/status", testSimpleHandler).Name("api.status")
+	app.Get("/status", testSimpleHandler).Name("status")
+	app.Get("/health", testSimpleHandler)
+	app.Domain("api.example.com").Get("/health", testSimpleHandler)
+
+	// only the named route is removed
+	app.RemoveRouteByName("status")
+	resp, err := app.Test(httptest.NewRequest(MethodGet, "/status", nil))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, StatusNotFound, resp.StatusCode)
+	resp, err = app.Test(httptest.NewRequest(MethodGet, "http://api.example.com/status", nil))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, StatusOK, resp.StatusCode)
+
+	// the domain route on the path is removed too
+	app.RemoveRoute("/health")
+	resp, err = app.Test(httptest.NewRequest(MethodGet, "/health", nil))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, StatusNotFound, resp.StatusCode)
+	resp, err = app.Test(httptest.NewRequest(MethodGet, "http://api.example.com/health", nil))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, StatusNotFound, resp.StatusCode)
+}
+
+// go test -run Test_App_RemoveRouteByName
+func Test_App_RemoveRouteByName(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	app.Domain("api.example.com").Get("/users", testSimpleHandler).Name("api.users")
+	app.Get("/users", testSimpleHandler).Name("users")
+	app.Post("/users", testSimpleHandler).Name("users")
+
+	app.RemoveRouteByName("api.users")
+	resp, err := app.Test(httptest.NewRequest(MethodGet, "http://api.example.com/users", nil))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, StatusOK, resp.StatusCode)
+	require.Equal(t, Route{}, app.GetRoute("api.users"))
+
+	app.RemoveRouteByName("users", MethodGet)
+	resp, err = app.Test(httptest.NewRequest(MethodGet, "/users", nil))
+	require.NoError(t, err, "app.Test(req)")
+	require.Equal(t, StatusMethodNotAllowed, resp.StatusCode)
+	require.Equal(t, MethodPost, app.GetRoute("users").Method)
+}
+
+// go test -run Test_App_RemoveRoute_Concurrent -race
+func Test_App_RemoveRoute_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	app.Get("/stable", testSimpleHandler)
+	handler := app.Handler()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			path := fmt.Sprintf("/plugin/%d", i%10)
+			app.Get(path, testSimpleHandler).Name(path)
+			app.RemoveRouteByName(path)
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		c := &fasthttp.RequestCtx{}
+		c.Request.Header.SetMethod(MethodGet)
+		c.URI().SetPath("/stable")
+		handler(c)
+		require.Equal(t, StatusOK, c.Response.StatusCode())
+	}
+	<-done
+
+	c := &fasthttp.RequestCtx{}
+	c.Request.Header.SetMethod(MethodGet)
+	c.URI().SetPath("/plugin/1")
+	handler(c)
+	require.Equal(t, StatusNotFound, c.Response.StatusCode())
+}
+
+// go test -run Test_App_Name_Concurrent -race
+func Test_App_Name_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	app.Get("/named", func(c Ctx) error {
+		return c.SendString(c.Route().Name)
+	})
+	handler := app.Handler()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			app.Name(fmt.Sprintf("named.%d", i))
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		c := &fasthttp.RequestCtx{}
+		c.Request.Header.SetMethod(MethodGet)
+		c.URI().SetPath("/named")
+		handler(c)
+		require.Equal(t, StatusOK, c.Response.StatusCode())
+	}
+	<-done
+
+	// The renamed route is served right away
+	c := &fasthttp.RequestCtx{}
+	c.Request.Header.SetMethod(MethodGet)
+	c.URI().SetPath("/named")
+	handler(c)
+	require.Equal(t, "named.99", string(c.Response.Body()))
+}
+
 //////////////////////////////////////////////
 ///////////////// BENCHMARKS /////////////////
 //////////////////////////////////////////////
@@ -661,7 +1051,7 @@ func Benchmark_Router_Next(b *testing.B) {
 	}
 	require.NoError(b, err)
 	require.True(b, res)
-	require.Equal(b, 4, c.indexRoute)
+	require.Equal(b, "/user/keys/:id", c.route.Path)
 }
 
 // go test -v ./... -run=^$ -bench=Benchmark_Route_Match -benchmem -count=4
@@ -845,6 +1235,91 @@ func Benchmark_Router_Github_API(b *testing.B) {
 	}
 }
 
+// bucketTreeStack divides the routes of a method by the first three characters
+// of their path, like the router did before the radix tree was introduced
+func bucketTreeStack(app *App, method string) map[string][]*Route {
+	buckets := make(map[string][]*Route)
+	for _, route := range app.stack[app.methodInt(method)] {
+		treePath := ""
+		if len(route.routeParser.segs) > 0 && len(route.routeParser.segs[0].Const) >= 3 {
+			treePath = route.routeParser.segs[0].Const[:3]
+		}
+		buckets[treePath] = append(buckets[treePath], route)
+	}
+	for treePath := range buckets {
+		if treePath != "" {
+			buckets[treePath] = uniqueRouteStack(append(buckets[treePath], buckets[""]...))
+		}
+		slc := buckets[treePath]
+		sort.Slice(slc, func(i, j int) bool { return slc[i].pos < slc[j].pos })
+	}
+	return buckets
+}
+
+func benchmarkRouteLookup(b *testing.B, find func(detectionPath string) []*Route, path string, found bool) {
+	b.Helper()
+	var params [maxParams]string
+	var matched *Route
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		matched = nil
+		for _, route := range find(path) {
+			if !route.use && route.match(path, path, &params) {
+				matched = route
+				break
+			}
+		}
+	}
+	require.Equal(b, found, matched != nil)
+}
+
+// go test -v -run=^$ -bench=Benchmark_Router_Tree -benchmem -count=4
+func Benchmark_Router_Tree(b *testing.B) {
+	app := New()
+	h := func(Ctx) error { return nil }
+	app.Use(h)
+	app.Use("/api", h)
+	for i := 0; i < 500; i++ {
+		app.Get(fmt.Sprintf("/api/v1/resource%d", i), h)
+		app.Get(fmt.Sprintf("/api/v1/resource%d/:id", i), h)
+		app.Get(fmt.Sprintf("/api/v1/resource%d/:id/items/*", i), h)
+		app.Post(fmt.Sprintf("/api/v1/resource%d", i), h)
+	}
+	registerDummyRoutes(app)
+	app.startupProcess()
+
+	buckets := bucketTreeStack(app, MethodGet)
+	tree := (*app.treeStack.Load())[app.methodInt(MethodGet)]
+
+	testCases := []struct {
+		name  string
+		path  string
+		found bool
+	}{
+		{name: "last_route", path: "/api/v1/resource499/42/items/7", found: true},
+		{name: "static_route", path: "/api/v1/resource250", found: true},
+		{name: "not_found", path: "/api/v1/missing", found: false},
+		{name: "github_api", path: "/repos/gofiber/fiber/git/refs/heads/main", found: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		b.Run(tc.name+"/radix", func(b *testing.B) {
+			benchmarkRouteLookup(b, tree.find, tc.path, tc.found)
+		})
+		b.Run(tc.name+"/buckets", func(b *testing.B) {
+			benchmarkRouteLookup(b, func(detectionPath string) []*Route {
+				if tree, ok := buckets[detectionPath[:3]]; ok {
+					return tree
+				}
+				return buckets[""]
+			}, tc.path, tc.found)
+		})
+	}
+}
+
 type testRoute struct {
 	Method string `json:"method"`
 	Path   string `json:"path"`
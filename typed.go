@@ -0,0 +1,107 @@
+// ⚡️ Fiber is an Express inspired web framework written in Go with ☕️
+// 🤖 Github Repository: https://github.com/gofiber/fiber
+// 📌 API Documentation: https://docs.gofiber.io
+
+package fiber
+
+import (
+	"reflect"
+)
+
+// HandlerTypes are the request and response types of a handler registered with AddTyped
+type HandlerTypes struct {
+	Request  reflect.Type
+	Response reflect.Type
+}
+
+// Typed creates a handler from a function with typed request and response.
+// The request is bound from the body, the query, the header and the route
+// parameters, in this order, and validated once with the StructValidator of
+// the app. Binding and validation errors are answered with 400 Bad Request,
+// unsupported body content types with 422 Unprocessable Entity. The response
+// is encoded with AutoFormat according to the Accept header, errors of the
+// function are passed to the error handler like the ones of other handlers.
+//
+//	app.Post("/users/:id", fiber.Typed(func(c fiber.Ctx, req UpdateUser) (User, error) {
+//	     return users.Update(req)
+//	}))
+//
+// Use AddTyped to make the types available with the Types field of the route.
+func Typed[Req, Resp any](handler func(c Ctx, req Req) (Resp, error)) Handler {
+	return func(c Ctx) error {
+		var req Req
+		if err := bindTyped(c, &req); err != nil {
+			return err
+		}
+		resp, err := handler(c, req)
+		if err != nil {
+			return err
+		}
+		return c.AutoFormat(resp)
+	}
+}
+
+// AddTyped registers the handler created with Typed from handler for the
+// methods and the path of the router, like Add, and records its request and
+// response types in the Types field of the routes.
+//
+//	fiber.AddTyped(app, []string{fiber.MethodPut}, "/users/:id", func(c fiber.Ctx, req UpdateUser) (User, error) {
+//	     return users.Update(req)
+//	})
+//
+// The types are only recorded for the routers of Fiber, other implementations
+// of Router register the handler with Add.
+func AddTyped[Req, Resp any](router Router, methods []string, path string, handler func(c Ctx, req Req) (Resp, error), middleware ...Handler) Router {
+	types := &HandlerTypes{
+		Request:  reflect.TypeOf((*Req)(nil)).Elem(),
+		Response: reflect.TypeOf((*Resp)(nil)).Elem(),
+	}
+
+	switch r := router.(type) {
+	case *App:
+		r.registerTyped(methods, path, nil, types, Typed(handler), middleware...)
+	case *Group:
+		r.app.registerTyped(methods, getGroupPath(r.Prefix, path), r, types, Typed(handler), middleware...)
+		if !r.anyRouteDefined {
+			r.anyRouteDefined = true
+		}
+	default:
+		router.Add(methods, path, Typed(handler), middleware...)
+	}
+
+	return router
+}
+
+// bindTyped binds the request into out and validates it once, the route
+// parameters are bound last to take precedence
+func bindTyped(c Ctx, out any) error {
+	// copy the binder to keep the mode of the context
+	bind := *c.Bind()
+	bind.should = false
+	bind.skipValidation = true
+
+	if len(c.Body()) > 0 {
+		if err := bind.Body(out); err != nil {
+			return err
+		}
+	}
+	if reflect.TypeOf(out).Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	if err := bind.Query(out); err != nil {
+		return err
+	}
+	if err := bind.Header(out); err != nil {
+		return err
+	}
+	if err := bind.URI(out); err != nil {
+		return err
+	}
+
+	if validator := c.App().config.StructValidator; validator != nil {
+		if err := validator.ValidateStruct(out); err != nil {
+			return NewError(StatusBadRequest, "Bad request: "+err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+// ⚡️ Fiber is an Express inspired web framework written in Go with ☕️
+// 🤖 Github Repository: https://github.com/gofiber/fiber
+// 📌 API Documentation: https://docs.gofiber.io
+
+//nolint:bodyclose // Much easier to just ignore memory leaks in tests
+package fiber
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type typedUserRequest struct {
+	ID    int    `uri:"id" json:"id"`
+	Page  int    `query:"page"`
+	Token string `header:"X-Token"`
+	Name  string `json:"name"`
+}
+
+type typedUserResponse struct {
+	ID    int    `json:"id" xml:"id"`
+	Page  int    `json:"page" xml:"page"`
+	Token string `json:"token" xml:"token"`
+	Name  string `json:"name" xml:"name"`
+}
+
+type typedValidator struct{}
+
+func (*typedValidator) Engine() any {
+	return ""
+}
+
+func (*typedValidator) ValidateStruct(out any) error {
+	if req, ok := out.(*typedUserRequest); ok && req.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+// go test -run Test_Typed
+func Test_Typed(t *testing.T) {
+	t.Parallel()
+
+	app := New(Config{StructValidator: &typedValidator{}})
+	AddTyped(app.Group("/users"), []string{MethodPut}, "/:id", func(c Ctx, req typedUserRequest) (typedUserResponse, error) {
+		if req.Name == "taken" {
+			return typedUserResponse{}, NewError(StatusConflict, "name is taken")
+		}
+		c.Status(StatusCreated)
+		return typedUserResponse(req), nil
+	}).Name("users.update")
+
+	testCases := []struct {
+		name        string
+		target      string
+		body        string
+		contentType string
+		accept      string
+		code        int
+		respBody    string
+	}{
+		{
+			name:        "bind all sources, route params take precedence",
+			target:      "/users/42?page=2",
+			body:        `{"id":1,"name":"john"}`,
+			contentType: MIMEApplicationJSON,
+			accept:      MIMEApplicationJSON,
+			code:        StatusCreated,
+			respBody:    `{"id":42,"page":2,"token":"secret","name":"john"}`,
+		},
+		{
+			name:        "encode according to accept",
+			target:      "/users/42",
+			body:        `{"name":"john"}`,
+			contentType: MIMEApplicationJSON,
+			accept:      MIMEApplicationXML,
+			code:        StatusCreated,
+			respBody:    `<typedUserResponse><id>42</id><page>0</page><token>secret</token><name>john</name></typedUserResponse>`,
+		},
+		{
+			name:        "invalid body",
+			target:      "/users/42",
+			body:        `{"name":`,
+			contentType: MIMEApplicationJSON,
+			code:        StatusBadRequest,
+			respBody:    "Bad request: unexpected end of JSON input",
+		},
+		{
+			name:     "invalid route param",
+			target:   "/users/abc",
+			code:     StatusBadRequest,
+			respBody: "Bad request: schema: error converting value for \"id\"",
+		},
+		{
+			name:     "validation",
+			target:   "/users/42",
+			code:     StatusBadRequest,
+			respBody: "Bad request: name is required",
+		},
+		{
+			name:        "unsupported content type",
+			target:      "/users/42",
+			body:        "name=john",
+			contentType: MIMETextPlain,
+			code:        StatusUnprocessableEntity,
+			respBody:    "Unprocessable Entity",
+		},
+		{
+			name:        "handler error",
+			target:      "/users/42",
+			body:        `{"name":"taken"}`,
+			contentType: MIMEApplicationJSON,
+			code:        StatusConflict,
+			respBody:    "name is taken",
+		},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(MethodPut, tc.target, strings.NewReader(tc.body))
+		req.Header.Set("X-Token", "secret")
+		if tc.contentType != "" {
+			req.Header.Set(HeaderContentType, tc.contentType)
+		}
+		if tc.accept != "" {
+			req.Header.Set(HeaderAccept, tc.accept)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err, "app.Test(req)")
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, tc.code, resp.StatusCode, tc.name)
+		require.Equal(t, tc.respBody, string(body), tc.name)
+	}
+
+	types := app.GetRoute("users.update").Types
+	require.NotNil(t, types)
+	require.Equal(t, reflect.TypeOf(typedUserRequest{}), types.Request)
+	require.Equal(t, reflect.TypeOf(typedUserResponse{}), types.Response)
+}
+
+// go test -run Test_Typed_NonStruct
+func Test_Typed_NonStruct(t *testing.T) {
+	t.Parallel()
+
+	app := New()
+	app.Use(func(c Ctx) error {
+		c.Set("X-Middleware", "true")
+		return c.Next()
+	})
+	AddTyped(app, []string{MethodPost}, "/sum", func(_ Ctx, numbers []int) (int, error) {
+		var sum int
+		for _, n := range numbers {
+			sum += n
+		}
+		return sum, nil
+	}, func(c Ctx) error {
+		return c.Next()
+	})
+	app.Get("/plain", testEmptyHandler)
+	// Typed alone doesn't record the types
+	app.Post("/untyped", Typed(func(_ Ctx, numbers []int) (int, error) {
+		return len(numbers), nil
+	}))
+
+	req := httptest.NewRequest(MethodPost, "/sum", strings.NewReader(`[1,2,3]`))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+	req.Header.Set(HeaderAccept, MIMETextPlain)
+	resp, err := app.Test(req)
+	require.NoError(t, err, "app.Test(req)")
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, StatusOK, resp.StatusCode)
+	require.Equal(t, "6", string(body))
+
+	for _, route := range app.GetRoutes(true) {
+		switch route.Path {
+		case "/sum":
+			require.NotNil(t, route.Types)
+			require.Equal(t, reflect.TypeOf([]int{}), route.Types.Request)
+			require.Equal(t, reflect.TypeOf(0), route.Types.Response)
+		default:
+			require.Nil(t, route.Types, route.Path)
+		}
+	}
+}